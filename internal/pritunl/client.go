@@ -2,50 +2,63 @@ package pritunl
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Client interface {
-	TestApiCall() error
-
-	GetOrganizations() ([]Organization, error)
-	GetOrganization(id string) (*Organization, error)
-	CreateOrganization(name string) (*Organization, error)
-	UpdateOrganization(id string, organization *Organization) error
-	DeleteOrganization(name string) error
-
-	GetUser(id string, orgId string) (*User, error)
-	CreateUser(newUser User) (*User, error)
-	UpdateUser(id string, user *User) error
-	DeleteUser(id string, orgId string) error
-
-	GetServers() ([]Server, error)
-	GetServer(id string) (*Server, error)
-	CreateServer(serverData map[string]interface{}) (*Server, error)
-	UpdateServer(id string, server *Server) error
-	DeleteServer(id string) error
-
-	GetOrganizationsByServer(serverId string) ([]Organization, error)
-	AttachOrganizationToServer(organizationId, serverId string) error
-	DetachOrganizationFromServer(organizationId, serverId string) error
-
-	GetRoutesByServer(serverId string) ([]Route, error)
-	AddRouteToServer(serverId string, route Route) error
-	AddRoutesToServer(serverId string, route []Route) error
-	DeleteRouteFromServer(serverId string, route Route) error
-	UpdateRouteOnServer(serverId string, route Route) error
-
-	GetHosts() ([]Host, error)
-	GetHostsByServer(serverId string) ([]Host, error)
-	AttachHostToServer(hostId, serverId string) error
-	DetachHostFromServer(hostId, serverId string) error
-
-	StartServer(serverId string) error
-	StopServer(serverId string) error
+	TestApiCall(ctx context.Context) error
+
+	GetOrganizations(ctx context.Context) ([]Organization, error)
+	GetOrganization(ctx context.Context, id string) (*Organization, error)
+	CreateOrganization(ctx context.Context, name string) (*Organization, error)
+	UpdateOrganization(ctx context.Context, id string, organization *Organization) error
+	DeleteOrganization(ctx context.Context, name string) error
+
+	GetUsers(ctx context.Context, orgId string, search string, page int) (*UsersPage, error)
+	GetUser(ctx context.Context, id string, orgId string) (*User, error)
+	CreateUser(ctx context.Context, newUser User) (*User, error)
+	UpdateUser(ctx context.Context, id string, user *User) error
+	DeleteUser(ctx context.Context, id string, orgId string) error
+	ResetUserOtpSecret(ctx context.Context, id string, orgId string) (*User, error)
+
+	GetUserKeyProfile(ctx context.Context, orgId string, userId string, serverId string) (string, error)
+	GetUserKeyLinks(ctx context.Context, orgId string, userId string) (*KeyLinks, error)
+
+	GetServers(ctx context.Context) ([]Server, error)
+	GetServer(ctx context.Context, id string) (*Server, error)
+	GetServerOutput(ctx context.Context, id string) ([]string, error)
+	CreateServer(ctx context.Context, serverData map[string]interface{}) (*Server, error)
+	UpdateServer(ctx context.Context, id string, server *Server, fields ...string) error
+	DeleteServer(ctx context.Context, id string) error
+
+	GetOrganizationsByServer(ctx context.Context, serverId string) ([]Organization, error)
+	AttachOrganizationToServer(ctx context.Context, organizationId, serverId string) error
+	DetachOrganizationFromServer(ctx context.Context, organizationId, serverId string) error
+
+	GetRoutesByServer(ctx context.Context, serverId string) ([]Route, error)
+	AddRouteToServer(ctx context.Context, serverId string, route Route) error
+	AddRoutesToServer(ctx context.Context, serverId string, route []Route) error
+	DeleteRouteFromServer(ctx context.Context, serverId string, route Route) error
+	UpdateRouteOnServer(ctx context.Context, serverId string, route Route) error
+
+	GetHosts(ctx context.Context) ([]Host, error)
+	GetHostsByServer(ctx context.Context, serverId string) ([]Host, error)
+	AttachHostToServer(ctx context.Context, hostId, serverId string) error
+	DetachHostFromServer(ctx context.Context, hostId, serverId string) error
+
+	StartServer(ctx context.Context, serverId string) error
+	StopServer(ctx context.Context, serverId string) error
+	RestartServer(ctx context.Context, serverId string) error
 }
 
 type client struct {
@@ -53,9 +66,9 @@ type client struct {
 	baseUrl    string
 }
 
-func (c client) TestApiCall() error {
+func (c client) TestApiCall(ctx context.Context) error {
 	url := fmt.Sprintf("/state")
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -64,21 +77,21 @@ func (c client) TestApiCall() error {
 	defer resp.Body.Close()
 	body, _ := io.ReadAll(resp.Body)
 
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("Non-200 response on the tests api call\ncode=%d\nbody=%s\n", resp.StatusCode, body)
-	}
-
 	// 401 - invalid credentials
 	if resp.StatusCode == 401 {
-		return fmt.Errorf("unauthorized: Invalid token or secret")
+		return fmt.Errorf("unauthorized: Invalid token or secret: %w", newAPIError(resp, body))
+	}
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("Non-200 response on the tests api call: %w", newAPIError(resp, body))
 	}
 
 	return nil
 }
 
-func (c client) GetOrganization(id string) (*Organization, error) {
+func (c client) GetOrganization(ctx context.Context, id string) (*Organization, error) {
 	url := fmt.Sprintf("/organization/%s", id)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -88,7 +101,7 @@ func (c client) GetOrganization(id string) (*Organization, error) {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Non-200 response on getting the organization\nbody=%s", body)
+		return nil, fmt.Errorf("Non-200 response on getting the organization: %w", newAPIError(resp, body))
 	}
 
 	var organization Organization
@@ -101,9 +114,9 @@ func (c client) GetOrganization(id string) (*Organization, error) {
 	return &organization, nil
 }
 
-func (c client) GetOrganizations() ([]Organization, error) {
+func (c client) GetOrganizations(ctx context.Context) ([]Organization, error) {
 	url := fmt.Sprintf("/organization")
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -113,7 +126,7 @@ func (c client) GetOrganizations() ([]Organization, error) {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Non-200 response on getting the organization\nbody=%s", body)
+		return nil, fmt.Errorf("Non-200 response on getting the organization: %w", newAPIError(resp, body))
 	}
 
 	var organizations []Organization
@@ -126,11 +139,11 @@ func (c client) GetOrganizations() ([]Organization, error) {
 	return organizations, nil
 }
 
-func (c client) CreateOrganization(name string) (*Organization, error) {
+func (c client) CreateOrganization(ctx context.Context, name string) (*Organization, error) {
 	var jsonStr = []byte(`{"name": "` + name + `"}`)
 
 	url := "/organization"
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonStr))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonStr))
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -140,7 +153,7 @@ func (c client) CreateOrganization(name string) (*Organization, error) {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Non-200 response on creating the organization\nbody=%s", body)
+		return nil, fmt.Errorf("Non-200 response on creating the organization: %w", newAPIError(resp, body))
 	}
 
 	var organization Organization
@@ -152,14 +165,14 @@ func (c client) CreateOrganization(name string) (*Organization, error) {
 	return &organization, nil
 }
 
-func (c client) UpdateOrganization(id string, organization *Organization) error {
+func (c client) UpdateOrganization(ctx context.Context, id string, organization *Organization) error {
 	jsonData, err := json.Marshal(organization)
 	if err != nil {
 		return fmt.Errorf("UpdateOrganization: Error on marshalling data: %s", err)
 	}
 
 	url := fmt.Sprintf("/organization/%s", id)
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(jsonData))
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -169,15 +182,15 @@ func (c client) UpdateOrganization(id string, organization *Organization) error
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Non-200 response on updating the organization\nbody=%s", body)
+		return fmt.Errorf("Non-200 response on updating the organization: %w", newAPIError(resp, body))
 	}
 
 	return nil
 }
 
-func (c client) DeleteOrganization(id string) error {
+func (c client) DeleteOrganization(ctx context.Context, id string) error {
 	url := fmt.Sprintf("/organization/%s", id)
-	req, err := http.NewRequest("DELETE", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -187,15 +200,15 @@ func (c client) DeleteOrganization(id string) error {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Non-200 response on deleting the organization\nbody=%s", body)
+		return fmt.Errorf("Non-200 response on deleting the organization: %w", newAPIError(resp, body))
 	}
 
 	return nil
 }
 
-func (c client) GetServer(id string) (*Server, error) {
+func (c client) GetServer(ctx context.Context, id string) (*Server, error) {
 	url := fmt.Sprintf("/server/%s", id)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -205,7 +218,7 @@ func (c client) GetServer(id string) (*Server, error) {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Non-200 response on getting the server\nbody=%s", body)
+		return nil, fmt.Errorf("Non-200 response on getting the server: %w", newAPIError(resp, body))
 	}
 
 	var server Server
@@ -218,9 +231,34 @@ func (c client) GetServer(id string) (*Server, error) {
 	return &server, nil
 }
 
-func (c client) GetServers() ([]Server, error) {
+// GetServerOutput returns the lines of the server output log.
+func (c client) GetServerOutput(ctx context.Context, id string) ([]string, error) {
+	url := fmt.Sprintf("/server/%s/output", id)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GetServerOutput: Error on HTTP request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("Non-200 response on getting the server output: %w", newAPIError(resp, body))
+	}
+
+	var output ServerOutput
+	err = json.Unmarshal(body, &output)
+	if err != nil {
+		return nil, fmt.Errorf("GetServerOutput: %s: id=%s, body=%s", err, id, body)
+	}
+
+	return output.Output, nil
+}
+
+func (c client) GetServers(ctx context.Context) ([]Server, error) {
 	url := fmt.Sprintf("/server")
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -230,7 +268,7 @@ func (c client) GetServers() ([]Server, error) {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Non-200 response on getting servers\nbody=%s", body)
+		return nil, fmt.Errorf("Non-200 response on getting servers: %w", newAPIError(resp, body))
 	}
 
 	var servers []Server
@@ -243,7 +281,7 @@ func (c client) GetServers() ([]Server, error) {
 	return servers, nil
 }
 
-func (c client) CreateServer(serverData map[string]interface{}) (*Server, error) {
+func (c client) CreateServer(ctx context.Context, serverData map[string]interface{}) (*Server, error) {
 	serverStruct := Server{}
 
 	if v, ok := serverData["name"]; ok {
@@ -418,7 +456,7 @@ func (c client) CreateServer(serverData map[string]interface{}) (*Server, error)
 	jsonData, err := serverStruct.MarshalJSON()
 
 	url := "/server"
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -428,7 +466,7 @@ func (c client) CreateServer(serverData map[string]interface{}) (*Server, error)
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Non-200 response on creating the server\ncode=%d\nbody=%s", resp.StatusCode, body)
+		return nil, fmt.Errorf("Non-200 response on creating the server: %w", newAPIError(resp, body))
 	}
 
 	var server Server
@@ -440,14 +478,22 @@ func (c client) CreateServer(serverData map[string]interface{}) (*Server, error)
 	return &server, nil
 }
 
-func (c client) UpdateServer(id string, server *Server) error {
-	jsonData, err := server.MarshalJSON()
+// UpdateServer sends the server to Pritunl. When fields are given, only those
+// fields are sent and the other attributes of the server are left untouched.
+func (c client) UpdateServer(ctx context.Context, id string, server *Server, fields ...string) error {
+	var jsonData []byte
+	var err error
+	if len(fields) > 0 {
+		jsonData, err = server.MarshalFields(fields)
+	} else {
+		jsonData, err = server.MarshalJSON()
+	}
 	if err != nil {
 		return fmt.Errorf("UpdateServer: Error on marshalling data: %s", err)
 	}
 
 	url := fmt.Sprintf("/server/%s", id)
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(jsonData))
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -457,15 +503,15 @@ func (c client) UpdateServer(id string, server *Server) error {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Non-200 response on updating the server\nbody=%s", body)
+		return fmt.Errorf("Non-200 response on updating the server: %w", newAPIError(resp, body))
 	}
 
 	return nil
 }
 
-func (c client) DeleteServer(id string) error {
+func (c client) DeleteServer(ctx context.Context, id string) error {
 	url := fmt.Sprintf("/server/%s", id)
-	req, err := http.NewRequest("DELETE", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -475,15 +521,15 @@ func (c client) DeleteServer(id string) error {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Non-200 response on deleting the server\nbody=%s", body)
+		return fmt.Errorf("Non-200 response on deleting the server: %w", newAPIError(resp, body))
 	}
 
 	return nil
 }
 
-func (c client) GetOrganizationsByServer(serverId string) ([]Organization, error) {
+func (c client) GetOrganizationsByServer(ctx context.Context, serverId string) ([]Organization, error) {
 	url := fmt.Sprintf("/server/%s/organization", serverId)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -493,7 +539,7 @@ func (c client) GetOrganizationsByServer(serverId string) ([]Organization, error
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Non-200 response on getting organizations on the server\nbody=%s", body)
+		return nil, fmt.Errorf("Non-200 response on getting organizations on the server: %w", newAPIError(resp, body))
 	}
 
 	var organizations []Organization
@@ -502,9 +548,9 @@ func (c client) GetOrganizationsByServer(serverId string) ([]Organization, error
 	return organizations, nil
 }
 
-func (c client) AttachOrganizationToServer(organizationId, serverId string) error {
+func (c client) AttachOrganizationToServer(ctx context.Context, organizationId, serverId string) error {
 	url := fmt.Sprintf("/server/%s/organization/%s", serverId, organizationId)
-	req, err := http.NewRequest("PUT", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -514,15 +560,15 @@ func (c client) AttachOrganizationToServer(organizationId, serverId string) erro
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Non-200 response on attaching an organization the server\nbody=%s", body)
+		return fmt.Errorf("Non-200 response on attaching an organization the server: %w", newAPIError(resp, body))
 	}
 
 	return nil
 }
 
-func (c client) DetachOrganizationFromServer(organizationId, serverId string) error {
+func (c client) DetachOrganizationFromServer(ctx context.Context, organizationId, serverId string) error {
 	url := fmt.Sprintf("/server/%s/organization/%s", serverId, organizationId)
-	req, err := http.NewRequest("DELETE", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -532,15 +578,15 @@ func (c client) DetachOrganizationFromServer(organizationId, serverId string) er
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Non-200 response on detaching the organization from the server\nbody=%s", body)
+		return fmt.Errorf("Non-200 response on detaching the organization from the server: %w", newAPIError(resp, body))
 	}
 
 	return nil
 }
 
-func (c client) StartServer(serverId string) error {
+func (c client) StartServer(ctx context.Context, serverId string) error {
 	url := fmt.Sprintf("/server/%s/operation/start", serverId)
-	req, err := http.NewRequest("PUT", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -550,15 +596,15 @@ func (c client) StartServer(serverId string) error {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Non-200 response on starting the server\nbody=%s", body)
+		return fmt.Errorf("Non-200 response on starting the server: %w", newAPIError(resp, body))
 	}
 
 	return nil
 }
 
-func (c client) StopServer(serverId string) error {
+func (c client) StopServer(ctx context.Context, serverId string) error {
 	url := fmt.Sprintf("/server/%s/operation/stop", serverId)
-	req, err := http.NewRequest("PUT", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -568,15 +614,33 @@ func (c client) StopServer(serverId string) error {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Non-200 response on stopping the server\nbody=%s", body)
+		return fmt.Errorf("Non-200 response on stopping the server: %w", newAPIError(resp, body))
 	}
 
 	return nil
 }
 
-func (c client) GetRoutesByServer(serverId string) ([]Route, error) {
+func (c client) RestartServer(ctx context.Context, serverId string) error {
+	url := fmt.Sprintf("/server/%s/operation/restart", serverId)
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, nil)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("RestartServer: Error on HTTP request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("Non-200 response on restarting the server: %w", newAPIError(resp, body))
+	}
+
+	return nil
+}
+
+func (c client) GetRoutesByServer(ctx context.Context, serverId string) ([]Route, error) {
 	url := fmt.Sprintf("/server/%s/route", serverId)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -586,7 +650,7 @@ func (c client) GetRoutesByServer(serverId string) ([]Route, error) {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Non-200 response on getting routes on the server\nbody=%s", body)
+		return nil, fmt.Errorf("Non-200 response on getting routes on the server: %w", newAPIError(resp, body))
 	}
 
 	var routes []Route
@@ -595,11 +659,11 @@ func (c client) GetRoutesByServer(serverId string) ([]Route, error) {
 	return routes, nil
 }
 
-func (c client) AddRouteToServer(serverId string, route Route) error {
+func (c client) AddRouteToServer(ctx context.Context, serverId string, route Route) error {
 	jsonData, err := json.Marshal(route)
 
 	url := fmt.Sprintf("/server/%s/route", serverId)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -609,17 +673,17 @@ func (c client) AddRouteToServer(serverId string, route Route) error {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Non-200 response on adding a route to the server\nbody=%s", body)
+		return fmt.Errorf("Non-200 response on adding a route to the server: %w", newAPIError(resp, body))
 	}
 
 	return nil
 }
 
-func (c client) AddRoutesToServer(serverId string, routes []Route) error {
+func (c client) AddRoutesToServer(ctx context.Context, serverId string, routes []Route) error {
 	jsonData, err := json.Marshal(routes)
 
 	url := fmt.Sprintf("/server/%s/routes", serverId)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -629,17 +693,17 @@ func (c client) AddRoutesToServer(serverId string, routes []Route) error {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Non-200 response on adding routes to the server\nbody=%s", body)
+		return fmt.Errorf("Non-200 response on adding routes to the server: %w", newAPIError(resp, body))
 	}
 
 	return nil
 }
 
-func (c client) UpdateRouteOnServer(serverId string, route Route) error {
+func (c client) UpdateRouteOnServer(ctx context.Context, serverId string, route Route) error {
 	jsonData, err := json.Marshal(route)
 
 	url := fmt.Sprintf("/server/%s/route/%s", serverId, route.GetID())
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(jsonData))
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -649,15 +713,15 @@ func (c client) UpdateRouteOnServer(serverId string, route Route) error {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Non-200 response on updating a route on the server\nbody=%s", body)
+		return fmt.Errorf("Non-200 response on updating a route on the server: %w", newAPIError(resp, body))
 	}
 
 	return nil
 }
 
-func (c client) DeleteRouteFromServer(serverId string, route Route) error {
+func (c client) DeleteRouteFromServer(ctx context.Context, serverId string, route Route) error {
 	url := fmt.Sprintf("/server/%s/route/%s", serverId, route.GetID())
-	req, err := http.NewRequest("DELETE", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -667,15 +731,47 @@ func (c client) DeleteRouteFromServer(serverId string, route Route) error {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Non-200 response on deleting a route on the server\nbody=%s", body)
+		return fmt.Errorf("Non-200 response on deleting a route on the server: %w", newAPIError(resp, body))
 	}
 
 	return nil
 }
 
-func (c client) GetUser(id string, orgId string) (*User, error) {
+// GetUsers returns a single page of the organization users. Pages are
+// numbered from 0, an empty search returns all users of the organization.
+func (c client) GetUsers(ctx context.Context, orgId string, search string, page int) (*UsersPage, error) {
+	query := neturl.Values{}
+	query.Set("page", strconv.Itoa(page))
+	if search != "" {
+		query.Set("search", search)
+	}
+
+	url := fmt.Sprintf("/user/%s?%s", orgId, query.Encode())
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GetUsers: Error on HTTP request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("Non-200 response on getting the users: %w", newAPIError(resp, body))
+	}
+
+	var usersPage UsersPage
+	err = json.Unmarshal(body, &usersPage)
+	if err != nil {
+		return nil, fmt.Errorf("GetUsers: %s: %+v, body=%s", err, usersPage, body)
+	}
+
+	return &usersPage, nil
+}
+
+func (c client) GetUser(ctx context.Context, id string, orgId string) (*User, error) {
 	url := fmt.Sprintf("/user/%s/%s", orgId, id)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -685,7 +781,7 @@ func (c client) GetUser(id string, orgId string) (*User, error) {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Non-200 response on getting the user\nbody=%s", body)
+		return nil, fmt.Errorf("Non-200 response on getting the user: %w", newAPIError(resp, body))
 	}
 
 	var user User
@@ -697,14 +793,14 @@ func (c client) GetUser(id string, orgId string) (*User, error) {
 	return &user, nil
 }
 
-func (c client) CreateUser(newUser User) (*User, error) {
+func (c client) CreateUser(ctx context.Context, newUser User) (*User, error) {
 	jsonData, err := json.Marshal(newUser)
 	if err != nil {
 		return nil, fmt.Errorf("CreateUser: Error on marshalling data: %s", err)
 	}
 
 	url := fmt.Sprintf("/user/%s", newUser.Organization)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -714,7 +810,7 @@ func (c client) CreateUser(newUser User) (*User, error) {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Non-200 response on creating the user\ncode=%d\nbody=%s", resp.StatusCode, body)
+		return nil, fmt.Errorf("Non-200 response on creating the user: %w", newAPIError(resp, body))
 	}
 
 	var users []User
@@ -730,14 +826,14 @@ func (c client) CreateUser(newUser User) (*User, error) {
 	return nil, fmt.Errorf("empty users response")
 }
 
-func (c client) UpdateUser(id string, user *User) error {
+func (c client) UpdateUser(ctx context.Context, id string, user *User) error {
 	jsonData, err := json.Marshal(user)
 	if err != nil {
 		return fmt.Errorf("UpdateUser: Error on marshalling data: %s", err)
 	}
 
 	url := fmt.Sprintf("/user/%s/%s", user.Organization, id)
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(jsonData))
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -747,15 +843,15 @@ func (c client) UpdateUser(id string, user *User) error {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Non-200 response on updating the user\nbody=%s", body)
+		return fmt.Errorf("Non-200 response on updating the user: %w", newAPIError(resp, body))
 	}
 
 	return nil
 }
 
-func (c client) DeleteUser(id string, orgId string) error {
+func (c client) DeleteUser(ctx context.Context, id string, orgId string) error {
 	url := fmt.Sprintf("/user/%s/%s", orgId, id)
-	req, err := http.NewRequest("DELETE", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -765,15 +861,96 @@ func (c client) DeleteUser(id string, orgId string) error {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Non-200 response on deleting the user\nbody=%s", body)
+		return fmt.Errorf("Non-200 response on deleting the user: %w", newAPIError(resp, body))
 	}
 
 	return nil
 }
 
-func (c client) GetHosts() ([]Host, error) {
+// ResetUserOtpSecret generates a new two-factor secret for the user and
+// returns the user with the new secret.
+func (c client) ResetUserOtpSecret(ctx context.Context, id string, orgId string) (*User, error) {
+	url := fmt.Sprintf("/user/%s/%s/otp_secret", orgId, id)
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, nil)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ResetUserOtpSecret: Error on HTTP request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("Non-200 response on resetting the user OTP secret: %w", newAPIError(resp, body))
+	}
+
+	var user User
+	err = json.Unmarshal(body, &user)
+	if err != nil {
+		return nil, fmt.Errorf("ResetUserOtpSecret: %s: id=%s", err, id)
+	}
+
+	return &user, nil
+}
+
+// GetUserKeyProfile returns the .ovpn profile of the user for the server.
+func (c client) GetUserKeyProfile(ctx context.Context, orgId string, userId string, serverId string) (string, error) {
+	url := fmt.Sprintf("/key/%s/%s/%s.key", orgId, userId, serverId)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("GetUserKeyProfile: Error on HTTP request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("GetUserKeyProfile: Error on reading response: %s", err)
+	}
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("Non-200 response on getting the user key profile: %w", newAPIError(resp, body))
+	}
+
+	return string(body), nil
+}
+
+// GetUserKeyLinks creates temporary links to download the profiles of the user.
+// The returned links are absolute URLs of the Pritunl server.
+func (c client) GetUserKeyLinks(ctx context.Context, orgId string, userId string) (*KeyLinks, error) {
+	url := fmt.Sprintf("/key/%s/%s", orgId, userId)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GetUserKeyLinks: Error on HTTP request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("Non-200 response on getting the user key links: %w", newAPIError(resp, body))
+	}
+
+	var keyLinks KeyLinks
+	err = json.Unmarshal(body, &keyLinks)
+	if err != nil {
+		return nil, fmt.Errorf("GetUserKeyLinks: %s: %+v, body=%s", err, keyLinks, body)
+	}
+
+	baseUrl := strings.TrimRight(c.baseUrl, "/")
+	for _, link := range []*string{&keyLinks.KeyUrl, &keyLinks.KeyZipUrl, &keyLinks.KeyOncUrl, &keyLinks.ViewUrl, &keyLinks.UriUrl} {
+		if *link != "" {
+			*link = baseUrl + *link
+		}
+	}
+
+	return &keyLinks, nil
+}
+
+func (c client) GetHosts(ctx context.Context) ([]Host, error) {
 	url := fmt.Sprintf("/host")
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -783,7 +960,7 @@ func (c client) GetHosts() ([]Host, error) {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Non-200 response on getting the hosts\nbody=%s", body)
+		return nil, fmt.Errorf("Non-200 response on getting the hosts: %w", newAPIError(resp, body))
 	}
 
 	var hosts []Host
@@ -796,9 +973,9 @@ func (c client) GetHosts() ([]Host, error) {
 	return hosts, nil
 }
 
-func (c client) GetHostsByServer(serverId string) ([]Host, error) {
+func (c client) GetHostsByServer(ctx context.Context, serverId string) ([]Host, error) {
 	url := fmt.Sprintf("/server/%s/host", serverId)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -808,7 +985,7 @@ func (c client) GetHostsByServer(serverId string) ([]Host, error) {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Non-200 response on getting hosts by the server\nbody=%s", body)
+		return nil, fmt.Errorf("Non-200 response on getting hosts by the server: %w", newAPIError(resp, body))
 	}
 
 	var hosts []Host
@@ -821,9 +998,9 @@ func (c client) GetHostsByServer(serverId string) ([]Host, error) {
 	return hosts, nil
 }
 
-func (c client) AttachHostToServer(hostId, serverId string) error {
+func (c client) AttachHostToServer(ctx context.Context, hostId, serverId string) error {
 	url := fmt.Sprintf("/server/%s/host/%s", serverId, hostId)
-	req, err := http.NewRequest("PUT", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -833,15 +1010,15 @@ func (c client) AttachHostToServer(hostId, serverId string) error {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Non-200 response on attachhing the host the server\nbody=%s", body)
+		return fmt.Errorf("Non-200 response on attachhing the host the server: %w", newAPIError(resp, body))
 	}
 
 	return nil
 }
 
-func (c client) DetachHostFromServer(hostId, serverId string) error {
+func (c client) DetachHostFromServer(ctx context.Context, hostId, serverId string) error {
 	url := fmt.Sprintf("/server/%s/host/%s", serverId, hostId)
-	req, err := http.NewRequest("DELETE", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -851,16 +1028,35 @@ func (c client) DetachHostFromServer(hostId, serverId string) error {
 
 	body, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Non-200 response on detaching the host from the server\nbody=%s", body)
+		return fmt.Errorf("Non-200 response on detaching the host from the server: %w", newAPIError(resp, body))
 	}
 
 	return nil
 }
 
-func NewClient(baseUrl, apiToken, apiSecret string, insecure bool) Client {
+func NewClient(baseUrl, apiToken, apiSecret string, insecure bool, opts ...Option) Client {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if cfg.proxyUrl != nil {
+		proxy = http.ProxyURL(cfg.proxyUrl)
+	}
+
 	underlyingTransport := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout: cfg.tlsHandshakeTimeout,
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: insecure,
+			RootCAs:            cfg.rootCAs,
+			Certificates:       cfg.clientCertificates,
+		},
 	}
 	httpClient := &http.Client{
 		Transport: &transport{
@@ -868,8 +1064,13 @@ func NewClient(baseUrl, apiToken, apiSecret string, insecure bool) Client {
 			apiToken:            apiToken,
 			apiSecret:           apiSecret,
 			underlyingTransport: underlyingTransport,
+			maxRetries:          cfg.maxRetries,
+			retryMinWait:        cfg.retryMinWait,
+			retryMaxWait:        cfg.retryMaxWait,
+			requestTimeout:      cfg.requestTimeout,
+			limiter:             newLimiter(cfg.maxConcurrentRequests, cfg.requestsPerSecond),
 		},
 	}
 
-	return &client{httpClient: httpClient}
+	return &client{httpClient: httpClient, baseUrl: baseUrl}
 }
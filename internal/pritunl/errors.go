@@ -0,0 +1,107 @@
+package pritunl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrNotFound is matched by errors.Is for any APIError with a 404 status code.
+var ErrNotFound = errors.New("pritunl: not found")
+
+// ErrorCodeServerNotOffline is the error code of the changes rejected by Pritunl
+// because the server is online.
+const ErrorCodeServerNotOffline = "server_not_offline"
+
+// APIError describes a non-200 response returned by the Pritunl API.
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+
+	// Code and Message are decoded from the Pritunl error body,
+	// e.g. {"error": "user_not_found", "error_msg": "User not found."}
+	Code    string
+	Message string
+
+	Body []byte
+}
+
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       body,
+	}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Path = resp.Request.URL.Path
+	}
+
+	var errorBody struct {
+		Error    string `json:"error"`
+		ErrorMsg string `json:"error_msg"`
+	}
+	if json.Unmarshal(body, &errorBody) == nil {
+		apiErr.Code = errorBody.Error
+		apiErr.Message = errorBody.ErrorMsg
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s returned %d %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+
+	switch {
+	case e.Message != "":
+		return fmt.Sprintf("%s: %s", msg, e.Message)
+	case e.Code != "":
+		return fmt.Sprintf("%s: %s", msg, e.Code)
+	case len(e.Body) > 0:
+		return fmt.Sprintf("%s\nbody=%s", msg, e.Body)
+	}
+
+	return msg
+}
+
+// Is allows matching an APIError with the ErrNotFound sentinel using errors.Is.
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// IsNotFound reports whether err is an APIError with a 404 status code.
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err is an APIError with a 401 status code.
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is an APIError with a 403 status code.
+func IsForbidden(err error) bool {
+	return hasStatusCode(err, http.StatusForbidden)
+}
+
+// IsServerNotOffline reports whether err is an APIError returned for a change
+// Pritunl only accepts while the server is offline.
+func IsServerNotOffline(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == ErrorCodeServerNotOffline
+	}
+
+	return false
+}
+
+func hasStatusCode(err error, statusCode int) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == statusCode
+	}
+
+	return false
+}
@@ -0,0 +1,12 @@
+package pritunl
+
+// KeyLinks are the temporary links to download the profiles of a user,
+// they expire after the key link timeout configured in Pritunl.
+type KeyLinks struct {
+	ID        string `json:"id"`
+	KeyUrl    string `json:"key_url"`
+	KeyZipUrl string `json:"key_zip_url"`
+	KeyOncUrl string `json:"key_onc_url"`
+	ViewUrl   string `json:"view_url"`
+	UriUrl    string `json:"uri_url"`
+}
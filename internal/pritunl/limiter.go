@@ -0,0 +1,71 @@
+package pritunl
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// limiter caps the number of in-flight requests and the request rate of a
+// client. A zero value doesn't limit anything.
+type limiter struct {
+	slots chan struct{}
+	rate  *rate.Limiter
+}
+
+func newLimiter(maxConcurrentRequests int, requestsPerSecond float64) *limiter {
+	l := &limiter{}
+
+	if maxConcurrentRequests > 0 {
+		l.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+
+	if requestsPerSecond > 0 {
+		burst := int(requestsPerSecond)
+		if burst < 1 {
+			burst = 1
+		}
+		l.rate = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+
+	return l
+}
+
+// acquire blocks until a request is allowed to be sent. The returned function
+// must be called once the request is finished to free its concurrency slot.
+func (l *limiter) acquire(ctx context.Context) (func(), error) {
+	if l.rate != nil {
+		if err := l.rate.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if l.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-l.slots })
+	}, nil
+}
+
+// releaseOnClose keeps a concurrency slot busy and the request context alive
+// until the response body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	defer r.release()
+	return r.ReadCloser.Close()
+}
@@ -0,0 +1,157 @@
+package pritunl
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const redactedValue = "***"
+
+// headers and JSON body fields that are never written to the logs, the key
+// links are temporary download links that don't need any other credential
+var (
+	redactedHeaders    = []string{"Auth-Token", "Auth-Signature"}
+	redactedBodyFields = []string{"pin", "otp_secret", "key_url", "key_zip_url", "key_onc_url", "view_url", "uri_url"}
+)
+
+// keyPath is the path of the API calls returning user profiles and their keys,
+// the response bodies of these calls are never written to the logs.
+const keyPath = "/key/"
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(b)
+}
+
+func logRequest(ctx context.Context, req *http.Request, requestID string, attempt int) {
+	fields := map[string]interface{}{
+		"request_id":  requestID,
+		"http_method": req.Method,
+		"http_path":   req.URL.Path,
+		"attempt":     attempt + 1,
+	}
+
+	tflog.Debug(ctx, "Sending Pritunl API request", fields)
+
+	fields["http_headers"] = redactHeaders(req.Header)
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			fields["http_body"] = redactBody(data)
+		}
+	}
+
+	tflog.Trace(ctx, "Pritunl API request details", fields)
+}
+
+// logResponse writes the response status and latency, and the redacted JSON
+// body at the TRACE level. The body is buffered, so it stays readable for the
+// caller.
+func logResponse(ctx context.Context, req *http.Request, resp *http.Response, err error, requestID string, latency time.Duration) {
+	fields := map[string]interface{}{
+		"request_id":  requestID,
+		"http_method": req.Method,
+		"http_path":   req.URL.Path,
+		"latency_ms":  latency.Milliseconds(),
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "Pritunl API request failed", fields)
+		return
+	}
+
+	fields["http_status"] = resp.StatusCode
+	tflog.Debug(ctx, "Received Pritunl API response", fields)
+
+	if strings.Contains(req.URL.Path, keyPath) {
+		fields["http_body"] = redactedValue
+		tflog.Trace(ctx, "Pritunl API response details", fields)
+		return
+	}
+
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		return
+	}
+
+	data, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if readErr != nil {
+		return
+	}
+
+	fields["http_body"] = redactBody(data)
+	tflog.Trace(ctx, "Pritunl API response details", fields)
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+	for name := range header {
+		result[name] = header.Get(name)
+	}
+
+	for _, name := range redactedHeaders {
+		if _, ok := result[name]; ok {
+			result[name] = redactedValue
+		}
+	}
+
+	return result
+}
+
+func redactBody(body []byte) string {
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(redactValue(data))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(redacted)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isRedactedBodyField(key) && item != nil {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+
+	return value
+}
+
+func isRedactedBodyField(key string) bool {
+	for _, field := range redactedBodyFields {
+		if key == field {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,82 @@
+package pritunl
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"time"
+)
+
+type config struct {
+	maxRetries   int
+	retryMinWait time.Duration
+	retryMaxWait time.Duration
+
+	maxConcurrentRequests int
+	requestsPerSecond     float64
+
+	rootCAs            *x509.CertPool
+	clientCertificates []tls.Certificate
+
+	requestTimeout      time.Duration
+	tlsHandshakeTimeout time.Duration
+	proxyUrl            *url.URL
+}
+
+// Option configures optional behaviour of the client returned by NewClient.
+type Option func(*config)
+
+// WithRetry enables retries of transient API failures. A failed request is
+// retried up to maxRetries times, waiting between minWait and maxWait with an
+// exponential backoff and jitter between the attempts.
+func WithRetry(maxRetries int, minWait, maxWait time.Duration) Option {
+	return func(c *config) {
+		c.maxRetries = maxRetries
+		c.retryMinWait = minWait
+		c.retryMaxWait = maxWait
+	}
+}
+
+// WithRateLimit limits the number of requests sent at the same time and the
+// number of requests sent per second by all goroutines sharing the client.
+// Zero disables the corresponding limit.
+func WithRateLimit(maxConcurrentRequests int, requestsPerSecond float64) Option {
+	return func(c *config) {
+		c.maxConcurrentRequests = maxConcurrentRequests
+		c.requestsPerSecond = requestsPerSecond
+	}
+}
+
+// WithRootCAs sets the certificate authorities used to verify the Pritunl
+// server certificate instead of the system ones.
+func WithRootCAs(rootCAs *x509.CertPool) Option {
+	return func(c *config) {
+		c.rootCAs = rootCAs
+	}
+}
+
+// WithClientCertificate sets the certificate presented to a server that
+// requires mutual TLS, e.g. a reverse proxy in front of the Pritunl console.
+func WithClientCertificate(certificate tls.Certificate) Option {
+	return func(c *config) {
+		c.clientCertificates = append(c.clientCertificates, certificate)
+	}
+}
+
+// WithTimeouts limits the time spent on every attempt of a client call and the
+// time spent on a TLS handshake. The wait for the rate limits and between the
+// retries isn't counted. Zero means no limit.
+func WithTimeouts(requestTimeout, tlsHandshakeTimeout time.Duration) Option {
+	return func(c *config) {
+		c.requestTimeout = requestTimeout
+		c.tlsHandshakeTimeout = tlsHandshakeTimeout
+	}
+}
+
+// WithProxy sends all requests through the given proxy instead of the one
+// configured by the HTTP_PROXY and HTTPS_PROXY environment variables.
+func WithProxy(proxyUrl *url.URL) Option {
+	return func(c *config) {
+		c.proxyUrl = proxyUrl
+	}
+}
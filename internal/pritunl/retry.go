@@ -0,0 +1,120 @@
+package pritunl
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// shouldRetry reports whether a failed request may be safely sent again.
+// Idempotent requests are retried on connection errors and on the statuses
+// Pritunl returns during host failover, other requests and server operations
+// are retried only when they never reached the server or were rate limited.
+// Certificate and configuration errors are never retried.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	idempotent := isIdempotent(req.Method) && !isOperation(req.URL.Path)
+
+	if err != nil {
+		if isPermanentError(err) {
+			return false
+		}
+
+		if idempotent {
+			return true
+		}
+
+		var opErr *net.OpError
+		return errors.As(err, &opErr) && opErr.Op == "dial"
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// isOperation reports whether path is an endpoint which triggers an action,
+// such as starting a server or generating a new OTP secret, repeating it
+// isn't safe even if the method is idempotent.
+func isOperation(path string) bool {
+	return strings.Contains(path, "/operation/") || strings.HasSuffix(path, "/otp_secret")
+}
+
+// isPermanentError reports whether err can't be resolved by sending the
+// request again, such as an untrusted certificate or an invalid URL.
+func isPermanentError(err error) bool {
+	var (
+		certificateVerificationErr *tls.CertificateVerificationError
+		recordHeaderErr            tls.RecordHeaderError
+		unknownAuthorityErr        x509.UnknownAuthorityError
+		hostnameErr                x509.HostnameError
+		certificateInvalidErr      x509.CertificateInvalidError
+		urlErr                     *url.Error
+		netErr                     net.Error
+	)
+
+	switch {
+	case errors.As(err, &certificateVerificationErr),
+		errors.As(err, &recordHeaderErr),
+		errors.As(err, &unknownAuthorityErr),
+		errors.As(err, &hostnameErr),
+		errors.As(err, &certificateInvalidErr):
+		return true
+	case errors.As(err, &urlErr):
+		return !errors.As(urlErr.Err, &netErr)
+	}
+
+	return false
+}
+
+// retryBackoff returns the time to wait before the next attempt. It honours
+// the Retry-After header and otherwise doubles minWait on every attempt, with
+// a random jitter, up to maxWait.
+func retryBackoff(attempt int, minWait, maxWait time.Duration, resp *http.Response) time.Duration {
+	if maxWait < minWait {
+		maxWait = minWait
+	}
+
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, maxWait)
+		}
+	}
+
+	wait := minWait
+	for i := 0; i < attempt && wait < maxWait; i++ {
+		wait *= 2
+	}
+	wait = min(wait, maxWait)
+
+	if wait <= 0 {
+		return 0
+	}
+
+	// apply a random jitter within [wait/2, wait]
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
@@ -2,6 +2,8 @@ package pritunl
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"strconv"
 )
 
 type Route struct {
@@ -21,6 +23,33 @@ type Route struct {
 	NatNetmap      string `json:"nat_netmap,omitempty"`
 }
 
+// UnmarshalJSON accepts the metric either as a number or as a string,
+// the Pritunl API expects it as a string on input.
+func (r *Route) UnmarshalJSON(data []byte) error {
+	type Alias Route
+	aux := &struct {
+		Metric interface{} `json:"metric"`
+		*Alias
+	}{
+		Alias: (*Alias)(r),
+	}
+
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+
+	switch v := aux.Metric.(type) {
+	case float64:
+		r.Metric = strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		r.Metric = v
+	default:
+		r.Metric = ""
+	}
+
+	return nil
+}
+
 func (r Route) GetID() string {
 	if len(r.Network) > 0 {
 		return hex.EncodeToString([]byte(r.Network))
@@ -44,6 +73,24 @@ func ConvertMapToRoute(data map[string]interface{}) Route {
 	if v, ok := data["net_gateway"]; ok {
 		route.NetGateway = v.(bool)
 	}
+	if v, ok := data["nat_interface"]; ok {
+		route.NatInterface = v.(string)
+	}
+	if v, ok := data["nat_netmap"]; ok {
+		route.NatNetmap = v.(string)
+	}
+	if v, ok := data["metric"]; ok && v.(int) > 0 {
+		route.Metric = strconv.Itoa(v.(int))
+	}
+	if v, ok := data["advertise"]; ok {
+		route.Advertise = v.(bool)
+	}
+	if v, ok := data["vpc_id"]; ok {
+		route.VpcID = v.(string)
+	}
+	if v, ok := data["vpc_region"]; ok {
+		route.VpcRegion = v.(string)
+	}
 
 	return route
 }
@@ -2,7 +2,10 @@ package pritunl
 
 import (
 	"encoding/json"
+	"fmt"
+	"reflect"
 	"strconv"
+	"strings"
 )
 
 const (
@@ -63,6 +66,42 @@ type Server struct {
 	Status           string   `json:"status,omitempty"`
 }
 
+// ServerOutput is the output log of the server processes.
+type ServerOutput struct {
+	ID     string   `json:"id"`
+	Output []string `json:"output"`
+}
+
+// UnmarshalJSON accepts the output as a list of lines or as a single string,
+// which is split into lines.
+func (o *ServerOutput) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ID     string          `json:"id"`
+		Output json.RawMessage `json:"output"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	o.ID = raw.ID
+	o.Output = nil
+	if len(raw.Output) == 0 || string(raw.Output) == "null" {
+		return nil
+	}
+
+	if err := json.Unmarshal(raw.Output, &o.Output); err == nil {
+		return nil
+	}
+
+	var output string
+	if err := json.Unmarshal(raw.Output, &output); err != nil {
+		return err
+	}
+	o.Output = strings.Split(strings.TrimRight(output, "\n"), "\n")
+
+	return nil
+}
+
 func (s *Server) MarshalJSON() ([]byte, error) {
 	type Alias Server
 	return json.Marshal(&struct {
@@ -74,3 +113,33 @@ func (s *Server) MarshalJSON() ([]byte, error) {
 		Alias:  (*Alias)(s),
 	})
 }
+
+// MarshalFields encodes only the given fields of the server, named by their
+// JSON keys. Unlike MarshalJSON, false and zero values of the fields are kept,
+// so an update request can turn an attribute off without resetting the others.
+func (s *Server) MarshalFields(fields []string) ([]byte, error) {
+	values := make(map[string]interface{}, len(fields))
+
+	serverValue := reflect.ValueOf(s).Elem()
+	serverType := serverValue.Type()
+	for i := 0; i < serverType.NumField(); i++ {
+		name := strings.Split(serverType.Field(i).Tag.Get("json"), ",")[0]
+		values[name] = serverValue.Field(i).Interface()
+	}
+
+	result := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		value, ok := values[field]
+		if !ok {
+			return nil, fmt.Errorf("unknown server field %s", field)
+		}
+		result[field] = value
+	}
+
+	// Pritunl API expects input mss_fix value as a string, but returns as an int
+	if _, ok := result["mss_fix"]; ok {
+		result["mss_fix"] = strconv.Itoa(s.MssFix)
+	}
+
+	return json.Marshal(result)
+}
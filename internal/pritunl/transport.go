@@ -1,17 +1,21 @@
 package pritunl
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/md5"
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type transport struct {
@@ -19,6 +23,16 @@ type transport struct {
 	apiToken            string
 	apiSecret           string
 	baseUrl             string
+
+	maxRetries   int
+	retryMinWait time.Duration
+	retryMaxWait time.Duration
+
+	// requestTimeout limits every attempt separately, it starts once the
+	// attempt is allowed by the limiter
+	requestTimeout time.Duration
+
+	limiter *limiter
 }
 
 func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -29,9 +43,78 @@ func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
 		}
 
 		u.Path = path.Join(u.Path, req.URL.Path)
+		u.RawQuery = req.URL.RawQuery
 		req.URL = u
 	}
 
+	ctx := req.Context()
+	requestID := newRequestID()
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		release, err := t.limiter.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		attemptCtx, cancel := ctx, context.CancelFunc(func() {})
+		if t.requestTimeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, t.requestTimeout)
+		}
+		done := func() {
+			cancel()
+			release()
+		}
+
+		attemptReq := req.WithContext(attemptCtx)
+		t.sign(attemptReq)
+		logRequest(ctx, attemptReq, requestID, attempt)
+
+		start := time.Now()
+		resp, err := t.underlyingTransport.RoundTrip(attemptReq)
+		logResponse(ctx, attemptReq, resp, err, requestID, time.Since(start))
+		if err != nil {
+			done()
+		} else {
+			resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: done}
+		}
+
+		if attempt >= t.maxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := retryBackoff(attempt, t.retryMinWait, t.retryMaxWait, resp)
+		tflog.Debug(ctx, "Retrying Pritunl API request", map[string]interface{}{
+			"request_id": requestID,
+			"wait_ms":    wait.Milliseconds(),
+		})
+
+		if resp != nil {
+			// drain the body to let the underlying connection be reused
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// sign sets the Pritunl API authentication headers. Every attempt gets a fresh
+// timestamp and nonce, as Pritunl rejects replayed nonces.
+func (t *transport) sign(req *http.Request) {
 	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
 	timestampNano := strconv.FormatInt(time.Now().UnixNano(), 10)
 
@@ -44,12 +127,10 @@ func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	mac.Write([]byte(authString))
 	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
 
-	req.Header.Add("Auth-Token", t.apiToken)
-	req.Header.Add("Auth-Timestamp", timestamp)
-	req.Header.Add("Auth-Nonce", nonce)
-	req.Header.Add("Auth-Signature", signature)
-
-	req.Header.Add("Content-Type", "application/json")
+	req.Header.Set("Auth-Token", t.apiToken)
+	req.Header.Set("Auth-Timestamp", timestamp)
+	req.Header.Set("Auth-Nonce", nonce)
+	req.Header.Set("Auth-Signature", signature)
 
-	return t.underlyingTransport.RoundTrip(req)
+	req.Header.Set("Content-Type", "application/json")
 }
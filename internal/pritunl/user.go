@@ -2,34 +2,71 @@ package pritunl
 
 import (
 	"encoding/json"
+	"fmt"
+	neturl "net/url"
 )
 
+// User is sent as a whole on update, so the attributes the user can change
+// are never omitted, otherwise they couldn't be set to false or emptied.
 type User struct {
-	ID              string                   `json:"id,omitempty"`
-	Name            string                   `json:"name"`
-	Type            string                   `json:"type,omitempty"`
-	AuthType        string                   `json:"auth_type,omitempty"`
-	DnsServers      []string                 `json:"dns_servers,omitempty"`
-	DnsSuffix       string                   `json:"dns_suffix,omitempty"`
-	DnsMapping      string                   `json:"dns_mapping,omitempty"`
-	Disabled        bool                     `json:"disabled,omitempty"`
-	NetworkLinks    []string                 `json:"network_links,omitempty"`
-	PortForwarding  []map[string]interface{} `json:"port_forwarding,omitempty"`
-	Email           string                   `json:"email,omitempty"`
-	Status          bool                     `json:"status,omitempty"`
-	OtpSecret       string                   `json:"otp_secret,omitempty"`
-	ClientToClient  bool                     `json:"client_to_client,omitempty"`
-	MacAddresses    []string                 `json:"mac_addresses,omitempty"`
-	YubicoID        string                   `json:"yubico_id,omitempty"`
-	SSO             interface{}              `json:"sso,omitempty"`
-	BypassSecondary bool                     `json:"bypass_secondary,omitempty"`
-	Groups          []string                 `json:"groups,omitempty"`
-	Audit           bool                     `json:"audit,omitempty"`
-	Gravatar        bool                     `json:"gravatar,omitempty"`
-	OtpAuth         bool                     `json:"otp_auth,omitempty"`
-	DeviceAuth      bool                     `json:"device_auth,omitempty"`
-	Organization    string                   `json:"organization,omitempty"`
-	Pin             *Pin                      `json:"pin,omitempty"`
+	ID               string                   `json:"id,omitempty"`
+	Name             string                   `json:"name"`
+	Type             string                   `json:"type,omitempty"`
+	AuthType         string                   `json:"auth_type,omitempty"`
+	DnsServers       []string                 `json:"dns_servers"`
+	DnsSuffix        string                   `json:"dns_suffix"`
+	DnsMapping       string                   `json:"dns_mapping,omitempty"`
+	Disabled         bool                     `json:"disabled"`
+	NetworkLinks     []string                 `json:"network_links"`
+	PortForwarding   []map[string]interface{} `json:"port_forwarding"`
+	Email            string                   `json:"email"`
+	Status           bool                     `json:"status,omitempty"`
+	OtpSecret        string                   `json:"otp_secret,omitempty"`
+	ClientToClient   bool                     `json:"client_to_client"`
+	MacAddresses     []string                 `json:"mac_addresses"`
+	YubicoID         string                   `json:"yubico_id"`
+	SSO              interface{}              `json:"sso,omitempty"`
+	BypassSecondary  bool                     `json:"bypass_secondary"`
+	Groups           []string                 `json:"groups"`
+	Audit            bool                     `json:"audit,omitempty"`
+	Gravatar         bool                     `json:"gravatar,omitempty"`
+	OtpAuth          bool                     `json:"otp_auth,omitempty"`
+	DeviceAuth       bool                     `json:"device_auth,omitempty"`
+	Organization     string                   `json:"organization,omitempty"`
+	OrganizationName string                   `json:"organization_name,omitempty"`
+	Pin              *Pin                     `json:"pin,omitempty"`
+}
+
+// OtpUri returns the provisioning URI of the two-factor secret in the format
+// of the Pritunl user profile page, empty if the user has no secret.
+func (u User) OtpUri() string {
+	if u.OtpSecret == "" {
+		return ""
+	}
+
+	label := neturl.PathEscape(fmt.Sprintf("%s@%s", u.Name, u.OrganizationName))
+
+	return fmt.Sprintf("otpauth://totp/%s?secret=%s", label, neturl.QueryEscape(u.OtpSecret))
+}
+
+// SSOName returns the single sign-on provider the user authenticated with,
+// empty if the user isn't a single sign-on user.
+func (u User) SSOName() string {
+	switch sso := u.SSO.(type) {
+	case string:
+		return sso
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", sso)
+	}
+}
+
+// UsersPage is a single page of the organization users list.
+type UsersPage struct {
+	Page      int    `json:"page"`
+	PageTotal int    `json:"page_total"`
+	Users     []User `json:"users"`
 }
 
 type PortForwarding struct {
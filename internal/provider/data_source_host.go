@@ -81,13 +81,13 @@ func dataSourceHost() *schema.Resource {
 	}
 }
 
-func dataSourceHostRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func dataSourceHostRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	hostname := d.Get("hostname")
 	filterFunction := func(host pritunl.Host) bool {
 		return host.Hostname == hostname
 	}
 
-	host, err := filterHosts(meta, filterFunction)
+	host, err := filterHosts(ctx, meta, filterFunction)
 	if err != nil {
 		return diag.Errorf("could not find host with a hostname %s. Previous error message: %v", hostname, err)
 	}
@@ -109,10 +109,10 @@ func dataSourceHostRead(_ context.Context, d *schema.ResourceData, meta interfac
 	return nil
 }
 
-func filterHosts(meta interface{}, test func(host pritunl.Host) bool) (pritunl.Host, error) {
+func filterHosts(ctx context.Context, meta interface{}, test func(host pritunl.Host) bool) (pritunl.Host, error) {
 	apiClient := meta.(pritunl.Client)
 
-	hosts, err := apiClient.GetHosts()
+	hosts, err := apiClient.GetHosts(ctx)
 
 	if err != nil {
 		return pritunl.Host{}, err
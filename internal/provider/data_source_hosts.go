@@ -24,10 +24,10 @@ func dataSourceHosts() *schema.Resource {
 	}
 }
 
-func dataSourceHostsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func dataSourceHostsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(pritunl.Client)
 
-	hosts, err := apiClient.GetHosts()
+	hosts, err := apiClient.GetHosts(ctx)
 	if err != nil {
 		return diag.Errorf("could not find any host. Previous error message: %v", err)
 	}
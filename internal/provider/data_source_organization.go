@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOrganization() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get information about the Pritunl organization.",
+		ReadContext: dataSourceOrganizationRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description:  "ID of organization",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"name": {
+				Description:  "Name of organization",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+		},
+	}
+}
+
+func dataSourceOrganizationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(pritunl.Client)
+
+	var organization *pritunl.Organization
+
+	if id, ok := d.GetOk("id"); ok {
+		var err error
+		organization, err = apiClient.GetOrganization(ctx, id.(string))
+		if err != nil {
+			return diag.Errorf("could not find organization with an id %s. Previous error message: %v", id, err)
+		}
+	} else {
+		name := d.Get("name").(string)
+		filterFunction := func(organization pritunl.Organization) bool {
+			return organization.Name == name
+		}
+
+		found, err := filterOrganizations(ctx, meta, filterFunction)
+		if err != nil {
+			return diag.Errorf("could not find organization with a name %s. Previous error message: %v", name, err)
+		}
+		organization = &found
+	}
+
+	d.SetId(organization.ID)
+	d.Set("name", organization.Name)
+
+	return nil
+}
+
+func filterOrganizations(ctx context.Context, meta interface{}, test func(organization pritunl.Organization) bool) (pritunl.Organization, error) {
+	apiClient := meta.(pritunl.Client)
+
+	organizations, err := apiClient.GetOrganizations(ctx)
+
+	if err != nil {
+		return pritunl.Organization{}, err
+	}
+
+	for _, dir := range organizations {
+		if test(dir) {
+			return dir, nil
+		}
+	}
+
+	return pritunl.Organization{}, errors.New("could not find an organization with specified parameters")
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestDataSourceOrganization(t *testing.T) {
+	orgName := "tfacc-org-ds1"
+	notExistOrgName := "not-exist-org"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testPritunlOrganizationDataSourceConfig(orgName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.pritunl_organization.by_name", "id", "pritunl_organization.test", "id"),
+					resource.TestCheckResourceAttr("data.pritunl_organization.by_id", "name", orgName),
+				),
+			},
+			{
+				Config:      testPritunlOrganizationDataSourceConfig(orgName) + testPritunlOrganizationByNameConfig(notExistOrgName),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("could not find organization with a name %s. Previous error message: could not find an organization with specified parameters", notExistOrgName)),
+			},
+		},
+	})
+}
+
+func testPritunlOrganizationDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "pritunl_organization" "test" {
+	name = "%[1]s"
+}
+
+data "pritunl_organization" "by_name" {
+	name = pritunl_organization.test.name
+}
+
+data "pritunl_organization" "by_id" {
+	id = pritunl_organization.test.id
+}
+`, name)
+}
+
+func testPritunlOrganizationByNameConfig(name string) string {
+	return fmt.Sprintf(`
+data "pritunl_organization" "not_exist" {
+	name = "%[1]s"
+}
+`, name)
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceOrganizations() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get a list of the Pritunl organizations.",
+		ReadContext: dataSourceOrganizationsRead,
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Description:  "A regex string to filter the organizations by name.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"organizations": {
+				Description: "A list of the Pritunl organizations resources.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOrganizationsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(pritunl.Client)
+
+	organizations, err := apiClient.GetOrganizations(ctx)
+	if err != nil {
+		return diag.Errorf("could not get organizations. Previous error message: %v", err)
+	}
+
+	nameRegex := d.Get("name_regex").(string)
+	var nameRegexp *regexp.Regexp
+	if nameRegex != "" {
+		nameRegexp = regexp.MustCompile(nameRegex)
+	}
+
+	resultOrganizations := make([]interface{}, 0)
+
+	for _, organization := range organizations {
+		if nameRegexp != nil && !nameRegexp.MatchString(organization.Name) {
+			continue
+		}
+
+		resultOrganizations = append(resultOrganizations, flattenOrganization(&organization))
+	}
+
+	if err = d.Set("organizations", resultOrganizations); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("organizations")
+
+	return nil
+}
+
+func flattenOrganization(organization *pritunl.Organization) interface{} {
+	result := map[string]interface{}{}
+
+	result["id"] = organization.ID
+	result["name"] = organization.Name
+
+	return result
+}
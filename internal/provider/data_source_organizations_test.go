@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestDataSourceOrganizations(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testPritunlOrganizationsConfig("tfacc-orgs-ds1", "tfacc-orgs-ds2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("num_organizations", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testPritunlOrganizationsConfig(name1, name2 string) string {
+	return fmt.Sprintf(`
+resource "pritunl_organization" "test1" {
+	name = "%[1]s"
+}
+
+resource "pritunl_organization" "test2" {
+	name = "%[2]s"
+}
+
+data "pritunl_organizations" "test" {
+	name_regex = "^tfacc-orgs-ds"
+
+	depends_on = [
+		pritunl_organization.test1,
+		pritunl_organization.test2,
+	]
+}
+
+output "num_organizations" {
+  value = length(data.pritunl_organizations.test.organizations)
+}
+`, name1, name2)
+}
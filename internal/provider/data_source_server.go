@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceServer() *schema.Resource {
+	dataSourceSchema := dataSourceServerSchema()
+
+	dataSourceSchema["id"] = &schema.Schema{
+		Description:  "ID of server",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+	}
+	dataSourceSchema["name"] = &schema.Schema{
+		Description:  "Name of server",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to get information about the Pritunl server.",
+		ReadContext: dataSourceServerRead,
+		Schema:      dataSourceSchema,
+	}
+}
+
+func dataSourceServerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(pritunl.Client)
+
+	var server *pritunl.Server
+
+	if id, ok := d.GetOk("id"); ok {
+		var err error
+		server, err = apiClient.GetServer(ctx, id.(string))
+		if err != nil {
+			return diag.Errorf("could not find server with an id %s. Previous error message: %v", id, err)
+		}
+	} else {
+		name := d.Get("name").(string)
+		filterFunction := func(server pritunl.Server) bool {
+			return server.Name == name
+		}
+
+		found, err := filterServers(ctx, meta, filterFunction)
+		if err != nil {
+			return diag.Errorf("could not find server with a name %s. Previous error message: %v", name, err)
+		}
+		server = &found
+	}
+
+	serverData, err := flattenServer(ctx, apiClient, server)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(server.ID)
+	for key, value := range serverData {
+		if key == "id" {
+			continue
+		}
+
+		if err = d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func filterServers(ctx context.Context, meta interface{}, test func(server pritunl.Server) bool) (pritunl.Server, error) {
+	apiClient := meta.(pritunl.Client)
+
+	servers, err := apiClient.GetServers(ctx)
+
+	if err != nil {
+		return pritunl.Server{}, err
+	}
+
+	for _, dir := range servers {
+		if test(dir) {
+			return dir, nil
+		}
+	}
+
+	return pritunl.Server{}, errors.New("could not find a server with specified parameters")
+}
+
+// dataSourceServerSchema returns the computed attributes of the server resource,
+// except for the ones not stored in Pritunl.
+func dataSourceServerSchema() map[string]*schema.Schema {
+	dataSourceSchema := dataSourceSchemaFromResourceSchema(resourceServer().Schema)
+	delete(dataSourceSchema, "restart_policy")
+
+	return dataSourceSchema
+}
+
+// flattenServer returns the server attributes together with its organizations, hosts and routes.
+func flattenServer(ctx context.Context, apiClient pritunl.Client, server *pritunl.Server) (map[string]interface{}, error) {
+	organizations, err := apiClient.GetOrganizationsByServer(ctx, server.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	routes, err := apiClient.GetRoutesByServer(ctx, server.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts, err := apiClient.GetHostsByServer(ctx, server.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationsList := make([]string, 0)
+	for _, organization := range organizations {
+		organizationsList = append(organizationsList, organization.ID)
+	}
+
+	hostsList := make([]string, 0)
+	for _, host := range hosts {
+		hostsList = append(hostsList, host.ID)
+	}
+
+	result := map[string]interface{}{}
+
+	result["id"] = server.ID
+	result["name"] = server.Name
+	result["protocol"] = server.Protocol
+	result["port"] = server.Port
+	result["cipher"] = server.Cipher
+	result["hash"] = server.Hash
+	result["network"] = server.Network
+	result["bind_address"] = server.BindAddress
+	result["groups"] = server.Groups
+	result["dns_servers"] = server.DnsServers
+	result["network_wg"] = server.NetworkWG
+	result["port_wg"] = server.PortWG
+	result["sso_auth"] = server.SsoAuth
+	result["otp_auth"] = server.OtpAuth
+	result["device_auth"] = server.DeviceAuth
+	result["dynamic_firewall"] = server.DynamicFirewall
+	result["ipv6"] = server.IPv6
+	result["dh_param_bits"] = server.DhParamBits
+	result["ping_interval"] = server.PingInterval
+	result["ping_timeout"] = server.PingTimeout
+	result["link_ping_interval"] = server.LinkPingInterval
+	result["link_ping_timeout"] = server.LinkPingTimeout
+	result["session_timeout"] = server.SessionTimeout
+	result["inactive_timeout"] = server.InactiveTimeout
+	result["max_clients"] = server.MaxClients
+	result["network_mode"] = server.NetworkMode
+	result["network_start"] = server.NetworkStart
+	result["network_end"] = server.NetworkEnd
+	result["mss_fix"] = server.MssFix
+	result["max_devices"] = server.MaxDevices
+	result["pre_connect_msg"] = server.PreConnectMsg
+	result["allowed_devices"] = server.AllowedDevices
+	result["search_domain"] = server.SearchDomain
+	result["replica_count"] = server.ReplicaCount
+	result["multi_device"] = server.MultiDevice
+	result["debug"] = server.Debug
+	result["restrict_routes"] = server.RestrictRoutes
+	result["block_outside_dns"] = server.BlockOutsideDns
+	result["dns_mapping"] = server.DnsMapping
+	result["inter_client"] = server.InterClient
+	result["vxlan"] = server.VxLan
+	result["geo_sort"] = server.GeoSort
+	result["status"] = server.Status
+	result["organization_ids"] = organizationsList
+	result["host_ids"] = hostsList
+	result["route"] = flattenRoutesData(routes)
+
+	return result, nil
+}
+
+// dataSourceSchemaFromResourceSchema converts a resource schema to a data source
+// schema with the same attributes, all of them computed.
+func dataSourceSchemaFromResourceSchema(resourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	result := make(map[string]*schema.Schema, len(resourceSchema))
+
+	for key, value := range resourceSchema {
+		attribute := &schema.Schema{
+			Type:        value.Type,
+			Description: value.Description,
+			Sensitive:   value.Sensitive,
+			Computed:    true,
+		}
+
+		switch elem := value.Elem.(type) {
+		case *schema.Resource:
+			attribute.Elem = &schema.Resource{Schema: dataSourceSchemaFromResourceSchema(elem.Schema)}
+		case *schema.Schema:
+			attribute.Elem = &schema.Schema{Type: elem.Type}
+		}
+
+		result[key] = attribute
+	}
+
+	return result
+}
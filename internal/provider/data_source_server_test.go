@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestDataSourceServer(t *testing.T) {
+	serverName := "tfacc-server-ds1"
+	orgName := "tfacc-server-ds-org1"
+	notExistServerName := "not-exist-server"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testPritunlServerDataSourceConfig(serverName, orgName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.pritunl_server.by_name", "id", "pritunl_server.test", "id"),
+					resource.TestCheckResourceAttr("data.pritunl_server.by_id", "name", serverName),
+					resource.TestCheckResourceAttrPair("data.pritunl_server.by_id", "port", "pritunl_server.test", "port"),
+					resource.TestCheckResourceAttrPair("data.pritunl_server.by_id", "network", "pritunl_server.test", "network"),
+					resource.TestCheckResourceAttrPair("data.pritunl_server.by_id", "organization_ids.0", "pritunl_organization.test", "id"),
+					resource.TestCheckResourceAttr("data.pritunl_server.by_id", "route.0.network", "10.2.0.0/24"),
+					resource.TestCheckResourceAttr("data.pritunl_server.by_id", "status", "offline"),
+				),
+			},
+			{
+				Config:      testPritunlServerDataSourceConfig(serverName, orgName) + testPritunlServerByNameConfig(notExistServerName),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("could not find server with a name %s. Previous error message: could not find a server with specified parameters", notExistServerName)),
+			},
+		},
+	})
+}
+
+func testPritunlServerDataSourceConfig(name, organizationName string) string {
+	return fmt.Sprintf(`
+resource "pritunl_organization" "test" {
+	name = "%[2]s"
+}
+
+resource "pritunl_server" "test" {
+	name             = "%[1]s"
+	organization_ids = [pritunl_organization.test.id]
+
+	route {
+		network = "10.2.0.0/24"
+		comment = "tfacc-route"
+	}
+}
+
+data "pritunl_server" "by_name" {
+	name = pritunl_server.test.name
+}
+
+data "pritunl_server" "by_id" {
+	id = pritunl_server.test.id
+}
+`, name, organizationName)
+}
+
+func testPritunlServerByNameConfig(name string) string {
+	return fmt.Sprintf(`
+data "pritunl_server" "not_exist" {
+	name = "%[1]s"
+}
+`, name)
+}
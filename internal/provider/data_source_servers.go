@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceServers() *schema.Resource {
+	serverSchema := dataSourceServerSchema()
+	serverSchema["id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to get a list of the Pritunl servers.",
+		ReadContext: dataSourceServersRead,
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Description:  "A regex string to filter the servers by name.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"status": {
+				Description:  "Filter the servers by status.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{pritunl.ServerStatusOnline, pritunl.ServerStatusOffline}, false),
+			},
+			"protocol": {
+				Description:  "Filter the servers by protocol.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"udp", "tcp"}, false),
+			},
+			"servers": {
+				Description: "A list of the Pritunl servers resources.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: serverSchema,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceServersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(pritunl.Client)
+
+	servers, err := apiClient.GetServers(ctx)
+	if err != nil {
+		return diag.Errorf("could not get servers. Previous error message: %v", err)
+	}
+
+	nameRegex := d.Get("name_regex").(string)
+	var nameRegexp *regexp.Regexp
+	if nameRegex != "" {
+		nameRegexp = regexp.MustCompile(nameRegex)
+	}
+	status := d.Get("status").(string)
+	protocol := d.Get("protocol").(string)
+
+	resultServers := make([]interface{}, 0)
+
+	for _, server := range servers {
+		if nameRegexp != nil && !nameRegexp.MatchString(server.Name) {
+			continue
+		}
+		if status != "" && server.Status != status {
+			continue
+		}
+		if protocol != "" && server.Protocol != protocol {
+			continue
+		}
+
+		serverData, err := flattenServer(ctx, apiClient, &server)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		resultServers = append(resultServers, serverData)
+	}
+
+	if err = d.Set("servers", resultServers); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("servers")
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestDataSourceServers(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testPritunlServersConfig("tfacc-servers-ds1", "tfacc-servers-ds2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("num_servers", "2"),
+					resource.TestCheckOutput("num_tcp_servers", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testPritunlServersConfig(name1, name2 string) string {
+	return fmt.Sprintf(`
+resource "pritunl_server" "test1" {
+	name     = "%[1]s"
+	protocol = "udp"
+}
+
+resource "pritunl_server" "test2" {
+	name     = "%[2]s"
+	protocol = "tcp"
+}
+
+data "pritunl_servers" "all" {
+	name_regex = "^tfacc-servers-ds"
+
+	depends_on = [
+		pritunl_server.test1,
+		pritunl_server.test2,
+	]
+}
+
+data "pritunl_servers" "tcp" {
+	name_regex = "^tfacc-servers-ds"
+	protocol   = "tcp"
+
+	depends_on = [
+		pritunl_server.test1,
+		pritunl_server.test2,
+	]
+}
+
+output "num_servers" {
+  value = length(data.pritunl_servers.all.servers)
+}
+
+output "num_tcp_servers" {
+  value = length(data.pritunl_servers.tcp.servers)
+}
+`, name1, name2)
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceUser() *schema.Resource {
+	dataSourceSchema := dataSourceUserSchema()
+
+	dataSourceSchema["organization_id"] = &schema.Schema{
+		Description:  "The ID of the organization the user belongs to.",
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringIsNotEmpty,
+	}
+	dataSourceSchema["name"] = &schema.Schema{
+		Description:  "Name of user",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"name", "email"},
+	}
+	dataSourceSchema["email"] = &schema.Schema{
+		Description:  "Email of user",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"name", "email"},
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to get information about the Pritunl user.",
+		ReadContext: dataSourceUserRead,
+		Schema:      dataSourceSchema,
+	}
+}
+
+func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	organizationId := d.Get("organization_id").(string)
+
+	var (
+		user pritunl.User
+		err  error
+	)
+
+	if name, ok := d.GetOk("name"); ok {
+		filterFunction := func(user pritunl.User) bool {
+			return user.Name == name.(string)
+		}
+
+		user, err = filterUsers(ctx, meta, organizationId, name.(string), filterFunction)
+		if err != nil {
+			return diag.Errorf("could not find user with a name %s. Previous error message: %v", name, err)
+		}
+	} else {
+		email := d.Get("email").(string)
+		filterFunction := func(user pritunl.User) bool {
+			return user.Email == email
+		}
+
+		user, err = filterUsers(ctx, meta, organizationId, "", filterFunction)
+		if err != nil {
+			return diag.Errorf("could not find user with an email %s. Previous error message: %v", email, err)
+		}
+	}
+
+	d.SetId(user.ID)
+	for key, value := range flattenUser(user) {
+		if key == "id" {
+			continue
+		}
+
+		if err = d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func filterUsers(ctx context.Context, meta interface{}, organizationId, search string, test func(user pritunl.User) bool) (pritunl.User, error) {
+	var result *pritunl.User
+
+	err := iterateUsers(ctx, meta, organizationId, search, func(user pritunl.User) bool {
+		if test(user) {
+			result = &user
+			return false
+		}
+
+		return true
+	})
+	if err != nil {
+		return pritunl.User{}, err
+	}
+
+	if result == nil {
+		return pritunl.User{}, errors.New("could not find a user with specified parameters")
+	}
+
+	return *result, nil
+}
+
+// iterateUsers calls fn for each user of the organization matching the search,
+// fetching the pages one by one until fn returns false or the last page is read.
+func iterateUsers(ctx context.Context, meta interface{}, organizationId, search string, fn func(user pritunl.User) bool) error {
+	apiClient := meta.(pritunl.Client)
+
+	for page := 0; ; page++ {
+		usersPage, err := apiClient.GetUsers(ctx, organizationId, search, page)
+		if err != nil {
+			return err
+		}
+
+		for _, user := range usersPage.Users {
+			if !fn(user) {
+				return nil
+			}
+		}
+
+		if len(usersPage.Users) == 0 || page >= usersPage.PageTotal {
+			return nil
+		}
+	}
+}
+
+// dataSourceUserSchema returns the computed attributes of the user resource,
+// except for the write-only ones.
+func dataSourceUserSchema() map[string]*schema.Schema {
+	dataSourceSchema := dataSourceSchemaFromResourceSchema(resourceUser().Schema)
+	delete(dataSourceSchema, "pin")
+	delete(dataSourceSchema, "pin_wo")
+	delete(dataSourceSchema, "pin_wo_version")
+	// the two-factor secrets are only exposed by the resource, so they aren't
+	// stored in the state for every user looked up
+	delete(dataSourceSchema, "otp_reset_trigger")
+	delete(dataSourceSchema, "otp_secret")
+	delete(dataSourceSchema, "otp_uri")
+
+	return dataSourceSchema
+}
+
+func flattenUser(user pritunl.User) map[string]interface{} {
+	result := map[string]interface{}{}
+
+	result["id"] = user.ID
+	result["name"] = user.Name
+	result["organization_id"] = user.Organization
+	result["groups"] = user.Groups
+	result["email"] = user.Email
+	result["disabled"] = user.Disabled
+	result["port_forwarding"] = user.PortForwarding
+	result["network_links"] = user.NetworkLinks
+	result["client_to_client"] = user.ClientToClient
+	result["type"] = user.Type
+	result["auth_type"] = user.AuthType
+	result["yubico_id"] = user.YubicoID
+	result["status"] = user.Status
+	result["dns_mapping"] = user.DnsMapping
+	result["audit"] = user.Audit
+	result["device_auth"] = user.DeviceAuth
+	result["otp_auth"] = user.OtpAuth
+	result["sso"] = user.SSOName()
+	result["mac_addresses"] = user.MacAddresses
+	result["dns_servers"] = user.DnsServers
+	result["dns_suffix"] = user.DnsSuffix
+	result["bypass_secondary"] = user.BypassSecondary
+	result["pin_set"] = user.Pin != nil && user.Pin.IsSet
+
+	return result
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceUserProfile() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get the VPN client profiles of the Pritunl user. The profiles contain the private key of the user and are stored in the Terraform state.",
+		ReadContext: dataSourceUserProfileRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Description:  "The ID of the organization the user belongs to.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"user_id": {
+				Description:  "The ID of the user.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"server_ids": {
+				Description: "The IDs of the servers to get the profiles for. Defaults to all servers attached to the organization.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"user_name": {
+				Description: "The name of the user.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"profiles": {
+				Description: "The .ovpn profiles of the user per server.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"server_id": {
+							Description: "The ID of the server.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"server_name": {
+							Description: "The name of the server.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"content": {
+							Description: "The contents of the .ovpn profile.",
+							Type:        schema.TypeString,
+							Computed:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUserProfileRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(pritunl.Client)
+
+	organizationId := d.Get("organization_id").(string)
+	userId := d.Get("user_id").(string)
+
+	user, err := apiClient.GetUser(ctx, userId, organizationId)
+	if err != nil {
+		return diag.Errorf("could not find user with an id %s. Previous error message: %v", userId, err)
+	}
+
+	servers, err := apiClient.GetServers(ctx)
+	if err != nil {
+		return diag.Errorf("could not get servers. Previous error message: %v", err)
+	}
+
+	serverNames := make(map[string]string, len(servers))
+	for _, server := range servers {
+		serverNames[server.ID] = server.Name
+	}
+
+	serverIds := make([]string, 0)
+	if v, ok := d.GetOk("server_ids"); ok {
+		for _, serverId := range v.([]interface{}) {
+			serverIds = append(serverIds, serverId.(string))
+		}
+	} else {
+		serverIds, err = organizationServerIds(ctx, apiClient, organizationId, servers)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	profiles := make([]interface{}, 0, len(serverIds))
+	for _, serverId := range serverIds {
+		content, err := apiClient.GetUserKeyProfile(ctx, organizationId, userId, serverId)
+		if err != nil {
+			return diag.Errorf("could not get profile of the user %s for the server %s. Previous error message: %v", user.Name, serverId, err)
+		}
+
+		profiles = append(profiles, map[string]interface{}{
+			"server_id":   serverId,
+			"server_name": serverNames[serverId],
+			"content":     content,
+		})
+	}
+
+	d.SetId(userId)
+	d.Set("user_name", user.Name)
+	d.Set("server_ids", serverIds)
+	if err = d.Set("profiles", profiles); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// organizationServerIds returns the IDs of the servers the organization is attached to.
+func organizationServerIds(ctx context.Context, apiClient pritunl.Client, organizationId string, servers []pritunl.Server) ([]string, error) {
+	serverIds := make([]string, 0)
+
+	for _, server := range servers {
+		organizations, err := apiClient.GetOrganizationsByServer(ctx, server.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, organization := range organizations {
+			if organization.ID == organizationId {
+				serverIds = append(serverIds, server.ID)
+				break
+			}
+		}
+	}
+
+	return serverIds, nil
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestDataSourceUserProfile(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testPritunlUserProfileConfig("tfacc-profile-user1", "tfacc-profile-org1", "tfacc-profile-server1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.pritunl_user_profile.test", "user_name", "pritunl_user.test", "name"),
+					resource.TestCheckResourceAttr("data.pritunl_user_profile.test", "profiles.#", "1"),
+					resource.TestCheckResourceAttrPair("data.pritunl_user_profile.test", "profiles.0.server_id", "pritunl_server.test", "id"),
+					resource.TestCheckResourceAttr("data.pritunl_user_profile.test", "profiles.0.server_name", "tfacc-profile-server1"),
+					resource.TestMatchResourceAttr("data.pritunl_user_profile.test", "profiles.0.content", regexp.MustCompile("BEGIN CERTIFICATE")),
+				),
+			},
+		},
+	})
+}
+
+func testPritunlUserProfileConfig(username, orgName, serverName string) string {
+	return fmt.Sprintf(`
+resource "pritunl_organization" "test" {
+	name = "%[2]s"
+}
+
+resource "pritunl_server" "test" {
+	name             = "%[3]s"
+	organization_ids = [pritunl_organization.test.id]
+}
+
+resource "pritunl_user" "test" {
+	name            = "%[1]s"
+	organization_id = pritunl_organization.test.id
+}
+
+data "pritunl_user_profile" "test" {
+	organization_id = pritunl_organization.test.id
+	user_id         = pritunl_user.test.id
+
+	depends_on = [pritunl_server.test]
+}
+`, username, orgName, serverName)
+}
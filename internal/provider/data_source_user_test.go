@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestDataSourceUser(t *testing.T) {
+	userName := "tfacc-user-ds1"
+	orgName := "tfacc-user-ds-org1"
+	notExistUserName := "not-exist-user"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testPritunlUserDataSourceConfig(userName, orgName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.pritunl_user.by_name", "id", "pritunl_user.test", "id"),
+					resource.TestCheckResourceAttrPair("data.pritunl_user.by_email", "id", "pritunl_user.test", "id"),
+					resource.TestCheckResourceAttr("data.pritunl_user.by_email", "name", userName),
+					resource.TestCheckResourceAttr("data.pritunl_user.by_name", "groups.0", "admins"),
+					resource.TestCheckResourceAttrPair("data.pritunl_user.by_name", "type", "pritunl_user.test", "type"),
+					resource.TestCheckResourceAttr("data.pritunl_user.by_name", "status", "false"),
+				),
+			},
+			{
+				Config:      testPritunlUserDataSourceConfig(userName, orgName) + testPritunlUserByNameConfig(notExistUserName),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("could not find user with a name %s. Previous error message: could not find a user with specified parameters", notExistUserName)),
+			},
+		},
+	})
+}
+
+func testPritunlUserDataSourceConfig(name, organizationName string) string {
+	return fmt.Sprintf(`
+resource "pritunl_organization" "test" {
+	name = "%[2]s"
+}
+
+resource "pritunl_user" "test" {
+	name            = "%[1]s"
+	organization_id = pritunl_organization.test.id
+	email           = "%[1]s@example.com"
+	groups          = ["admins"]
+}
+
+data "pritunl_user" "by_name" {
+	organization_id = pritunl_organization.test.id
+	name            = pritunl_user.test.name
+}
+
+data "pritunl_user" "by_email" {
+	organization_id = pritunl_organization.test.id
+	email           = pritunl_user.test.email
+}
+`, name, organizationName)
+}
+
+func testPritunlUserByNameConfig(name string) string {
+	return fmt.Sprintf(`
+data "pritunl_user" "not_exist" {
+	organization_id = pritunl_organization.test.id
+	name            = "%[1]s"
+}
+`, name)
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceUsers() *schema.Resource {
+	userSchema := dataSourceUserSchema()
+	userSchema["id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to get a list of the Pritunl users of an organization.",
+		ReadContext: dataSourceUsersRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Description:  "The ID of the organization the users belong to.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"search": {
+				Description: "A search string passed to Pritunl to filter the users, e.g. a part of the user name.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"group": {
+				Description: "Filter the users by group.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"auth_type": {
+				Description: "Filter the users by authentication type.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"disabled": {
+				Description: "Filter the users by the disabled flag.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"users": {
+				Description: "A list of the Pritunl users resources.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: userSchema,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	organizationId := d.Get("organization_id").(string)
+	search := d.Get("search").(string)
+	group := d.Get("group").(string)
+	authType := d.Get("auth_type").(string)
+
+	// the zero value of disabled is a valid filter, so check whether it's set in the config
+	disabledFilter := !d.GetRawConfig().GetAttr("disabled").IsNull()
+	disabled := d.Get("disabled").(bool)
+
+	resultUsers := make([]interface{}, 0)
+
+	err := iterateUsers(ctx, meta, organizationId, search, func(user pritunl.User) bool {
+		if group != "" && !containsString(user.Groups, group) {
+			return true
+		}
+		if authType != "" && user.AuthType != authType {
+			return true
+		}
+		if disabledFilter && user.Disabled != disabled {
+			return true
+		}
+
+		resultUsers = append(resultUsers, flattenUser(user))
+
+		return true
+	})
+	if err != nil {
+		return diag.Errorf("could not get users. Previous error message: %v", err)
+	}
+
+	if err = d.Set("users", resultUsers); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("users")
+
+	return nil
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
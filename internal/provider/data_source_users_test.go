@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestDataSourceUsers(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testPritunlUsersConfig("tfacc-users-ds-org1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("num_users", "3"),
+					resource.TestCheckOutput("num_admins", "2"),
+					resource.TestCheckOutput("num_disabled", "1"),
+					resource.TestCheckOutput("num_enabled", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testPritunlUsersConfig(organizationName string) string {
+	return fmt.Sprintf(`
+resource "pritunl_organization" "test" {
+	name = "%[1]s"
+}
+
+resource "pritunl_user" "test1" {
+	name            = "tfacc-users-ds1"
+	organization_id = pritunl_organization.test.id
+	groups          = ["admins"]
+}
+
+resource "pritunl_user" "test2" {
+	name            = "tfacc-users-ds2"
+	organization_id = pritunl_organization.test.id
+	groups          = ["admins"]
+	disabled        = true
+}
+
+resource "pritunl_user" "test3" {
+	name            = "tfacc-users-ds3"
+	organization_id = pritunl_organization.test.id
+}
+
+data "pritunl_users" "all" {
+	organization_id = pritunl_organization.test.id
+	search          = "tfacc-users-ds"
+
+	depends_on = [
+		pritunl_user.test1,
+		pritunl_user.test2,
+		pritunl_user.test3,
+	]
+}
+
+data "pritunl_users" "admins" {
+	organization_id = pritunl_organization.test.id
+	group           = "admins"
+
+	depends_on = [
+		pritunl_user.test1,
+		pritunl_user.test2,
+		pritunl_user.test3,
+	]
+}
+
+data "pritunl_users" "disabled" {
+	organization_id = pritunl_organization.test.id
+	disabled        = true
+
+	depends_on = [
+		pritunl_user.test1,
+		pritunl_user.test2,
+		pritunl_user.test3,
+	]
+}
+
+data "pritunl_users" "enabled" {
+	organization_id = pritunl_organization.test.id
+	disabled        = false
+
+	depends_on = [
+		pritunl_user.test1,
+		pritunl_user.test2,
+		pritunl_user.test3,
+	]
+}
+
+output "num_users" {
+  value = length(data.pritunl_users.all.users)
+}
+
+output "num_admins" {
+  value = length(data.pritunl_users.admins.users)
+}
+
+output "num_disabled" {
+  value = length(data.pritunl_users.disabled.users)
+}
+
+output "num_enabled" {
+  value = length(data.pritunl_users.enabled.users)
+}
+`, organizationName)
+}
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type userProfileEphemeralResource struct {
+	apiClient pritunl.Client
+}
+
+type userProfileEphemeralResourceModel struct {
+	OrganizationId types.String `tfsdk:"organization_id"`
+	UserId         types.String `tfsdk:"user_id"`
+	ServerId       types.String `tfsdk:"server_id"`
+	KeyUrl         types.String `tfsdk:"key_url"`
+	KeyZipUrl      types.String `tfsdk:"key_zip_url"`
+	KeyOncUrl      types.String `tfsdk:"key_onc_url"`
+	ViewUrl        types.String `tfsdk:"view_url"`
+	UriUrl         types.String `tfsdk:"uri_url"`
+	Profile        types.String `tfsdk:"profile"`
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &userProfileEphemeralResource{}
+
+func newUserProfileEphemeralResource() ephemeral.EphemeralResource {
+	return &userProfileEphemeralResource{}
+}
+
+func (r *userProfileEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_profile"
+}
+
+func (r *userProfileEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this ephemeral resource to get temporary profile links or the VPN client profile of the Pritunl user without storing them in the Terraform state. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Description: "The ID of the organization the user belongs to.",
+				Required:    true,
+			},
+			"user_id": schema.StringAttribute{
+				Description: "The ID of the user.",
+				Required:    true,
+			},
+			"server_id": schema.StringAttribute{
+				Description: "The ID of the server to get the .ovpn profile for.",
+				Optional:    true,
+			},
+			"key_url": schema.StringAttribute{
+				Description: "Temporary link to download the profiles of the user as a tar archive.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"key_zip_url": schema.StringAttribute{
+				Description: "Temporary link to download the profiles of the user as a zip archive.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"key_onc_url": schema.StringAttribute{
+				Description: "Temporary link to download the profiles of the user for Chromebooks.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"view_url": schema.StringAttribute{
+				Description: "Temporary link to the profile page of the user.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"uri_url": schema.StringAttribute{
+				Description: "Temporary link to import the profiles of the user in the Pritunl client.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"profile": schema.StringAttribute{
+				Description: "The contents of the .ovpn profile for `server_id`, null when `server_id` isn't set.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (r *userProfileEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	apiClient, ok := req.ProviderData.(pritunl.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected pritunl.Client, got %T", req.ProviderData))
+		return
+	}
+
+	r.apiClient = apiClient
+}
+
+func (r *userProfileEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data userProfileEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizationId := data.OrganizationId.ValueString()
+	userId := data.UserId.ValueString()
+
+	keyLinks, err := r.apiClient.GetUserKeyLinks(ctx, organizationId, userId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error on getting the user key links", err.Error())
+		return
+	}
+
+	data.KeyUrl = types.StringValue(keyLinks.KeyUrl)
+	data.KeyZipUrl = types.StringValue(keyLinks.KeyZipUrl)
+	data.KeyOncUrl = types.StringValue(keyLinks.KeyOncUrl)
+	data.ViewUrl = types.StringValue(keyLinks.ViewUrl)
+	data.UriUrl = types.StringValue(keyLinks.UriUrl)
+	data.Profile = types.StringNull()
+
+	if serverId := data.ServerId.ValueString(); serverId != "" {
+		profile, err := r.apiClient.GetUserKeyProfile(ctx, organizationId, userId, serverId)
+		if err != nil {
+			resp.Diagnostics.AddError("Error on getting the user key profile", err.Error())
+			return
+		}
+
+		data.Profile = types.StringValue(profile)
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+// protoV5ProviderFactories serve the muxed provider, required by the ephemeral
+// resources. The ephemeral resources require Terraform 1.10 or later.
+var protoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"pritunl": func() (tfprotov5.ProviderServer, error) {
+		providerServer, err := NewProviderServer(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		return providerServer(), nil
+	},
+}
+
+func TestEphemeralUserProfile(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// the ephemeral values aren't stored in the state, they're
+				// checked by the postconditions of the config
+				Config: testPritunlEphemeralUserProfileConfig("tfacc-ephemeral-user1", "tfacc-ephemeral-org1", "tfacc-ephemeral-server1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("pritunl_user.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testPritunlEphemeralUserProfileConfig(username, orgName, serverName string) string {
+	return fmt.Sprintf(`
+resource "pritunl_organization" "test" {
+	name = "%[2]s"
+}
+
+resource "pritunl_server" "test" {
+	name             = "%[3]s"
+	organization_ids = [pritunl_organization.test.id]
+}
+
+resource "pritunl_user" "test" {
+	name            = "%[1]s"
+	organization_id = pritunl_organization.test.id
+}
+
+ephemeral "pritunl_user_profile" "test" {
+	organization_id = pritunl_organization.test.id
+	user_id         = pritunl_user.test.id
+	server_id       = pritunl_server.test.id
+
+	lifecycle {
+		postcondition {
+			condition     = self.profile != null && strcontains(self.profile, "client")
+			error_message = "The ephemeral profile isn't an OpenVPN client profile."
+		}
+
+		postcondition {
+			condition     = alltrue([for link in [self.key_url, self.key_zip_url, self.key_onc_url, self.view_url, self.uri_url] : link != ""])
+			error_message = "The ephemeral key links are empty."
+		}
+	}
+}
+`, username, orgName, serverName)
+}
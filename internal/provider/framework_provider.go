@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// frameworkProvider serves the features the SDK doesn't support, such as
+// ephemeral resources. It's muxed with the SDK provider and reuses the API
+// client configured by the SDK provider.
+type frameworkProvider struct {
+	sdkProvider *schema.Provider
+}
+
+var _ fwprovider.ProviderWithEphemeralResources = &frameworkProvider{}
+
+// NewFrameworkProvider returns the framework provider muxed with sdkProvider.
+// The SDK provider must be configured first, so it must precede the framework
+// provider in the muxed servers.
+func NewFrameworkProvider(sdkProvider *schema.Provider) fwprovider.Provider {
+	return &frameworkProvider{sdkProvider: sdkProvider}
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "pritunl"
+}
+
+// Schema returns the provider schema of the SDK provider, the muxed providers
+// must have identical schemas.
+func (p *frameworkProvider) Schema(ctx context.Context, req fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	attributes := make(map[string]fwschema.Attribute, len(p.sdkProvider.Schema))
+
+	for name, attribute := range p.sdkProvider.Schema {
+		// the SDK reports required attributes with a default value as optional
+		required := attribute.Required
+		if required && attribute.DefaultFunc != nil {
+			if v, err := attribute.DefaultFunc(); err != nil || v != nil {
+				required = false
+			}
+		}
+		optional := !required
+
+		switch attribute.Type {
+		case schema.TypeString:
+			attributes[name] = fwschema.StringAttribute{Required: required, Optional: optional, Sensitive: attribute.Sensitive, Description: attribute.Description}
+		case schema.TypeBool:
+			attributes[name] = fwschema.BoolAttribute{Required: required, Optional: optional, Sensitive: attribute.Sensitive, Description: attribute.Description}
+		case schema.TypeInt:
+			attributes[name] = fwschema.Int64Attribute{Required: required, Optional: optional, Sensitive: attribute.Sensitive, Description: attribute.Description}
+		case schema.TypeFloat:
+			attributes[name] = fwschema.Float64Attribute{Required: required, Optional: optional, Sensitive: attribute.Sensitive, Description: attribute.Description}
+		default:
+			resp.Diagnostics.AddError("Unsupported provider attribute", fmt.Sprintf("the provider attribute %s has an unsupported type %s", name, attribute.Type))
+			return
+		}
+	}
+
+	resp.Schema = fwschema.Schema{Attributes: attributes}
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	apiClient, ok := p.sdkProvider.Meta().(pritunl.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unconfigured provider", "the Pritunl API client isn't configured")
+		return
+	}
+
+	resp.EphemeralResourceData = apiClient
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newUserProfileEphemeralResource,
+	}
+}
+
+// NewProviderServer returns the factory of the provider server muxing the SDK
+// provider and the framework provider.
+func NewProviderServer(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	sdkProvider := Provider()
+
+	muxServer, err := tf5muxserver.NewMuxServer(
+		ctx,
+		sdkProvider.GRPCProvider,
+		providerserver.NewProtocol5(NewFrameworkProvider(sdkProvider)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer, nil
+}
@@ -2,10 +2,16 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	neturl "net/url"
+	"os"
+	"time"
 
 	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
@@ -36,15 +42,110 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("PRITUNL_CONNECTION_CHECK", true),
 			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PRITUNL_MAX_RETRIES", 3),
+				Description:  "Maximum number of retries of failed API calls. Idempotent calls are retried on a connection error or a 429, 502, 503 or 504 status, other calls and server operations only when the connection couldn't be established or on a 429 status. Certificate errors are never retried. Set to 0 to disable retries.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_min_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PRITUNL_RETRY_MIN_WAIT", 1),
+				Description:  "Minimum time in seconds to wait before retrying a failed API call.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PRITUNL_RETRY_MAX_WAIT", 30),
+				Description:  "Maximum time in seconds to wait before retrying a failed API call.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PRITUNL_MAX_CONCURRENT_REQUESTS", 0),
+				Description:  "Maximum number of API calls sent to Pritunl at the same time. Set to 0 for no limit.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PRITUNL_REQUESTS_PER_SECOND", 0.0),
+				Description:  "Maximum number of API calls sent to Pritunl per second. Set to 0 for no limit.",
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("PRITUNL_CA_CERT_PEM", nil),
+				Description:   "PEM-encoded certificate authorities trusted in addition to the system ones when verifying the Pritunl server certificate.",
+				ConflictsWith: []string{"ca_cert_file"},
+			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("PRITUNL_CA_CERT_FILE", nil),
+				Description:   "Path to a file with PEM-encoded certificate authorities trusted in addition to the system ones when verifying the Pritunl server certificate.",
+				ConflictsWith: []string{"ca_cert_pem"},
+			},
+			"client_cert_pem": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PRITUNL_CLIENT_CERT_PEM", nil),
+				Description:  "PEM-encoded client certificate used for mutual TLS authentication.",
+				RequiredWith: []string{"client_key_pem"},
+			},
+			"client_key_pem": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.EnvDefaultFunc("PRITUNL_CLIENT_KEY_PEM", nil),
+				Description:  "PEM-encoded private key of the client certificate used for mutual TLS authentication.",
+				RequiredWith: []string{"client_cert_pem"},
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PRITUNL_REQUEST_TIMEOUT", 300),
+				Description:  "Maximum time in seconds for a single attempt of an API call. The wait for the rate limits and between the retries isn't counted. Set to 0 for no limit.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"tls_handshake_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PRITUNL_TLS_HANDSHAKE_TIMEOUT", 10),
+				Description:  "Maximum time in seconds to wait for a TLS handshake with the Pritunl server. Set to 0 for no limit.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PRITUNL_PROXY_URL", nil),
+				Description:  "URL of the proxy used to reach the Pritunl API, e.g. http://proxy.local:3128. Overrides the HTTP_PROXY and HTTPS_PROXY environment variables.",
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"pritunl_organization": resourceOrganization(),
-			"pritunl_server":       resourceServer(),
-			"pritunl_user":         resourceUser(),
+			"pritunl_organization":                   resourceOrganization(),
+			"pritunl_route":                          resourceRoute(),
+			"pritunl_server":                         resourceServer(),
+			"pritunl_server_host_attachment":         resourceServerHostAttachment(),
+			"pritunl_server_organization_attachment": resourceServerOrganizationAttachment(),
+			"pritunl_user":                           resourceUser(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"pritunl_host":  dataSourceHost(),
-			"pritunl_hosts": dataSourceHosts(),
+			"pritunl_host":          dataSourceHost(),
+			"pritunl_hosts":         dataSourceHosts(),
+			"pritunl_organization":  dataSourceOrganization(),
+			"pritunl_organizations": dataSourceOrganizations(),
+			"pritunl_server":        dataSourceServer(),
+			"pritunl_servers":       dataSourceServers(),
+			"pritunl_user":          dataSourceUser(),
+			"pritunl_user_profile":  dataSourceUserProfile(),
+			"pritunl_users":         dataSourceUsers(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -56,12 +157,63 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	secret := d.Get("secret").(string)
 	insecure := d.Get("insecure").(bool)
 	connectionCheck := d.Get("connection_check").(bool)
+	maxRetries := d.Get("max_retries").(int)
+	retryMinWait := time.Duration(d.Get("retry_min_wait").(int)) * time.Second
+	retryMaxWait := time.Duration(d.Get("retry_max_wait").(int)) * time.Second
+	maxConcurrentRequests := d.Get("max_concurrent_requests").(int)
+	requestsPerSecond := d.Get("requests_per_second").(float64)
+	requestTimeout := time.Duration(d.Get("request_timeout").(int)) * time.Second
+	tlsHandshakeTimeout := time.Duration(d.Get("tls_handshake_timeout").(int)) * time.Second
+
+	options := []pritunl.Option{
+		pritunl.WithRetry(maxRetries, retryMinWait, retryMaxWait),
+		pritunl.WithRateLimit(maxConcurrentRequests, requestsPerSecond),
+		pritunl.WithTimeouts(requestTimeout, tlsHandshakeTimeout),
+	}
+
+	if v := d.Get("proxy_url").(string); v != "" {
+		proxyUrl, err := neturl.Parse(v)
+		if err != nil {
+			return nil, diag.Errorf("failed to parse proxy_url: %s", err)
+		}
+		options = append(options, pritunl.WithProxy(proxyUrl))
+	}
+
+	caCertPem := d.Get("ca_cert_pem").(string)
+	if caCertFile := d.Get("ca_cert_file").(string); caCertFile != "" {
+		data, err := os.ReadFile(caCertFile)
+		if err != nil {
+			return nil, diag.Errorf("failed to read ca_cert_file: %s", err)
+		}
+		caCertPem = string(data)
+	}
+
+	if caCertPem != "" {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM([]byte(caCertPem)) {
+			return nil, diag.Errorf("failed to parse CA certificates: no valid PEM-encoded certificates found")
+		}
+		options = append(options, pritunl.WithRootCAs(rootCAs))
+	}
+
+	clientCertPem := d.Get("client_cert_pem").(string)
+	clientKeyPem := d.Get("client_key_pem").(string)
+	if clientCertPem != "" || clientKeyPem != "" {
+		certificate, err := tls.X509KeyPair([]byte(clientCertPem), []byte(clientKeyPem))
+		if err != nil {
+			return nil, diag.Errorf("failed to load client certificate: %s", err)
+		}
+		options = append(options, pritunl.WithClientCertificate(certificate))
+	}
 
-	apiClient := pritunl.NewClient(url, token, secret, insecure)
+	apiClient := pritunl.NewClient(url, token, secret, insecure, options...)
 
 	if connectionCheck {
 		// execute test api call to ensure that provided credentials are valid and pritunl api works
-		err := apiClient.TestApiCall()
+		err := apiClient.TestApiCall(ctx)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -31,7 +32,7 @@ func TestMain(m *testing.M) {
 	insecure, _ := strconv.ParseBool(os.Getenv("PRITUNL_INSECURE"))
 
 	testClient = pritunl.NewClient(url, token, secret, insecure)
-	err := testClient.TestApiCall()
+	err := testClient.TestApiCall(context.Background())
 	if err != nil {
 		panic(err)
 	}
@@ -74,7 +75,7 @@ func pritunlUserImportStep(name string) resource.TestStep {
 		ResourceName:            name,
 		ImportState:             true,
 		ImportStateVerify:       true,
-		ImportStateVerifyIgnore: []string{"pin"},
+		ImportStateVerifyIgnore: []string{"pin", "pin_wo_version", "otp_reset_trigger"},
 		ImportStateIdFunc: func(state *terraform.State) (string, error) {
 			userId := state.RootModule().Resources["pritunl_user.test"].Primary.Attributes["id"]
 			orgId := state.RootModule().Resources["pritunl_organization.test"].Primary.Attributes["id"]
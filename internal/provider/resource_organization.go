@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -31,8 +32,14 @@ func resourceOrganization() *schema.Resource {
 func resourceReadOrganization(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(pritunl.Client)
 
-	organization, err := apiClient.GetOrganization(d.Id())
+	organization, err := apiClient.GetOrganization(ctx, d.Id())
 	if err != nil {
+		if !d.IsNewResource() && pritunl.IsNotFound(err) {
+			tflog.Warn(ctx, "organization not found, removing from state", map[string]interface{}{"id": d.Id()})
+			d.SetId("")
+			return nil
+		}
+
 		return diag.FromErr(err)
 	}
 
@@ -44,8 +51,8 @@ func resourceReadOrganization(ctx context.Context, d *schema.ResourceData, meta
 func resourceDeleteOrganization(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(pritunl.Client)
 
-	err := apiClient.DeleteOrganization(d.Id())
-	if err != nil {
+	err := apiClient.DeleteOrganization(ctx, d.Id())
+	if err != nil && !pritunl.IsNotFound(err) {
 		return diag.FromErr(err)
 	}
 
@@ -57,7 +64,7 @@ func resourceDeleteOrganization(ctx context.Context, d *schema.ResourceData, met
 func resourceUpdateOrganization(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(pritunl.Client)
 
-	organization, err := apiClient.GetOrganization(d.Id())
+	organization, err := apiClient.GetOrganization(ctx, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -65,7 +72,7 @@ func resourceUpdateOrganization(ctx context.Context, d *schema.ResourceData, met
 	if d.HasChange("name") {
 		organization.Name = d.Get("name").(string)
 
-		err = apiClient.UpdateOrganization(d.Id(), organization)
+		err = apiClient.UpdateOrganization(ctx, d.Id(), organization)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -77,7 +84,7 @@ func resourceUpdateOrganization(ctx context.Context, d *schema.ResourceData, met
 func resourceCreateOrganization(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(pritunl.Client)
 
-	organization, err := apiClient.CreateOrganization(d.Get("name").(string))
+	organization, err := apiClient.CreateOrganization(ctx, d.Get("name").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
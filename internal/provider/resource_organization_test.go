@@ -1,8 +1,10 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"testing"
 )
 
@@ -28,6 +30,30 @@ func TestAccPritunlOrganization(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("removes an organization deleted outside of terraform from state", func(t *testing.T) {
+		orgName := "tfacc-org1"
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config:             testPritunlOrganizationConfig(orgName),
+					Check:              testPritunlOrganizationDisappears("pritunl_organization.test"),
+					ExpectNonEmptyPlan: true,
+				},
+			},
+		})
+	})
+}
+
+func testPritunlOrganizationDisappears(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		organizationId := s.RootModule().Resources[name].Primary.ID
+
+		return testClient.DeleteOrganization(context.Background(), organizationId)
+	}
 }
 
 func testPritunlOrganizationConfig(name string) string {
@@ -0,0 +1,267 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceRoute() *schema.Resource {
+	return &schema.Resource{
+		Description: "The route resource allows managing a single route of a Pritunl server. An online server is restarted to apply route changes, with `restart_policy = \"never\"` the apply fails instead. Leave out the `route` blocks of the `pritunl_server` whose routes are managed with this resource, the server removes the routes missing from its blocks.",
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the server the route belongs to.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"network": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Network address with subnet to route",
+				ValidateFunc: validation.IsCIDR,
+			},
+			"comment": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Comment for route",
+			},
+			"nat": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "NAT vpn traffic destined to this network",
+			},
+			"nat_interface": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Interface used for NAT, leave blank to detect the interface automatically.",
+			},
+			"nat_netmap": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Network address with subnet to map the routed network to with NAT netmap.",
+				ValidateFunc: validation.IsCIDR,
+			},
+			"net_gateway": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Net Gateway vpn traffic destined to this network",
+			},
+			"metric": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Route metric, leave blank to use the default metric.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"advertise": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Advertise the route to the VPC route table of the cloud provider.",
+			},
+			"vpc_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of the VPC the route is advertised to.",
+			},
+			"vpc_region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The region of the VPC the route is advertised to.",
+			},
+			"network_link": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Shows if the route is created from a user network link.",
+			},
+			"server_link": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Shows if the route is created from a linked server.",
+			},
+			"restart_policy": serverChangeRestartPolicySchema(),
+		},
+		CreateContext: resourceRouteCreate,
+		ReadContext:   resourceRouteRead,
+		UpdateContext: resourceRouteUpdate,
+		DeleteContext: resourceRouteDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRouteImport,
+		},
+	}
+}
+
+func resourceRouteRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(pritunl.Client)
+
+	serverId := d.Get("server_id").(string)
+	network := d.Get("network").(string)
+
+	routes, err := apiClient.GetRoutesByServer(ctx, serverId)
+	if err != nil {
+		if !d.IsNewResource() && pritunl.IsNotFound(err) {
+			tflog.Warn(ctx, "server of the route not found, removing from state", map[string]interface{}{"id": d.Id()})
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	route, found := findRoute(routes, network)
+	if !found {
+		if !d.IsNewResource() {
+			tflog.Warn(ctx, "route not found, removing from state", map[string]interface{}{"id": d.Id()})
+			d.SetId("")
+			return nil
+		}
+
+		return diag.Errorf("route %s is not found on the server %s", network, serverId)
+	}
+
+	d.Set("comment", route.Comment)
+	d.Set("nat", route.Nat)
+	d.Set("nat_interface", route.NatInterface)
+	d.Set("nat_netmap", route.NatNetmap)
+	d.Set("net_gateway", route.NetGateway)
+	d.Set("advertise", route.Advertise)
+	d.Set("vpc_id", route.VpcID)
+	d.Set("vpc_region", route.VpcRegion)
+	d.Set("network_link", route.NetworkLink)
+	d.Set("server_link", route.ServerLink)
+
+	metric := 0
+	if route.Metric != "" {
+		metric, err = strconv.Atoi(route.Metric)
+		if err != nil {
+			return diag.Errorf("failed to parse metric of the route %s: %s", network, err)
+		}
+	}
+	d.Set("metric", metric)
+
+	return nil
+}
+
+func resourceRouteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(pritunl.Client)
+
+	serverId := d.Get("server_id").(string)
+	route := expandRoute(d)
+
+	err := withServerStopped(ctx, apiClient, serverId, d.Get("restart_policy").(string), func() error {
+		return apiClient.AddRouteToServer(ctx, serverId, route)
+	})
+	if err != nil {
+		return diag.Errorf("Error on adding route to the server: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", serverId, route.Network))
+
+	return resourceRouteRead(ctx, d, meta)
+}
+
+func resourceRouteUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(pritunl.Client)
+
+	// restart_policy only applies to the changes of the route
+	if !d.HasChangeExcept("restart_policy") {
+		return resourceRouteRead(ctx, d, meta)
+	}
+
+	serverId := d.Get("server_id").(string)
+	route := expandRoute(d)
+
+	err := withServerStopped(ctx, apiClient, serverId, d.Get("restart_policy").(string), func() error {
+		return apiClient.UpdateRouteOnServer(ctx, serverId, route)
+	})
+	if err != nil {
+		return diag.Errorf("Error on updating route on the server: %s", err)
+	}
+
+	return resourceRouteRead(ctx, d, meta)
+}
+
+func resourceRouteDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(pritunl.Client)
+
+	serverId := d.Get("server_id").(string)
+	route := expandRoute(d)
+
+	err := withServerStopped(ctx, apiClient, serverId, d.Get("restart_policy").(string), func() error {
+		return apiClient.DeleteRouteFromServer(ctx, serverId, route)
+	})
+	if err != nil && !pritunl.IsNotFound(err) {
+		return diag.Errorf("Error on deleting route from the server: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceRouteImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	apiClient := meta.(pritunl.Client)
+
+	attributes := strings.SplitN(d.Id(), "/", 2)
+	if len(attributes) < 2 || attributes[0] == "" || attributes[1] == "" {
+		return nil, fmt.Errorf("invalid format: expected ${serverId}/${network}, e.g. 60cd0be07723cf3c9114686c/10.0.0.0/24, actual id is %s", d.Id())
+	}
+
+	serverId := attributes[0]
+	network := attributes[1]
+
+	routes, err := apiClient.GetRoutesByServer(ctx, serverId)
+	if err != nil {
+		return nil, fmt.Errorf("error on getting routes during import: %s", err)
+	}
+
+	if _, found := findRoute(routes, network); !found {
+		return nil, fmt.Errorf("route %s is not found on the server %s", network, serverId)
+	}
+
+	d.Set("server_id", serverId)
+	d.Set("network", network)
+
+	d.Set("restart_policy", serverRestartPolicyAuto)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandRoute(d *schema.ResourceData) pritunl.Route {
+	route := pritunl.Route{
+		Network:      d.Get("network").(string),
+		Comment:      d.Get("comment").(string),
+		Nat:          d.Get("nat").(bool),
+		NatInterface: d.Get("nat_interface").(string),
+		NatNetmap:    d.Get("nat_netmap").(string),
+		NetGateway:   d.Get("net_gateway").(bool),
+		Advertise:    d.Get("advertise").(bool),
+		VpcID:        d.Get("vpc_id").(string),
+		VpcRegion:    d.Get("vpc_region").(string),
+	}
+
+	if v, ok := d.GetOk("metric"); ok {
+		route.Metric = strconv.Itoa(v.(int))
+	}
+
+	return route
+}
+
+func findRoute(routes []pritunl.Route, network string) (pritunl.Route, bool) {
+	for _, route := range routes {
+		if route.Network == network {
+			return route, true
+		}
+	}
+
+	return pritunl.Route{}, false
+}
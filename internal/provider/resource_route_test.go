@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPritunlRoute(t *testing.T) {
+
+	t.Run("creates a route without error", func(t *testing.T) {
+		serverName := "tfacc-server1"
+		network := "10.5.0.0/24"
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: testPritunlRouteConfig(serverName, network, "tfacc-route"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("pritunl_route.test", "network", network),
+						resource.TestCheckResourceAttr("pritunl_route.test", "comment", "tfacc-route"),
+						resource.TestCheckResourceAttr("pritunl_route.test", "nat", "true"),
+						resource.TestCheckResourceAttr("pritunl_route.test", "metric", "10"),
+					),
+				},
+				{
+					Config: testPritunlRouteConfig(serverName, network, "tfacc-route-updated"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("pritunl_route.test", "comment", "tfacc-route-updated"),
+					),
+				},
+				// import test
+				importStep("pritunl_route.test"),
+				// the route is removed while the server still exists
+				{
+					Config: testPritunlRouteServerConfig(serverName),
+					Check:  testPritunlRouteRemoved(network),
+				},
+			},
+		})
+	})
+
+	t.Run("fails to add a route to an online server with restart_policy never", func(t *testing.T) {
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config:      testPritunlRouteOnlineServerConfig("tfacc-server1", "tfacc-org1", "10.5.0.0/24", "never"),
+					ExpectError: regexp.MustCompile("forbidden by restart_policy = never"),
+				},
+			},
+		})
+	})
+}
+
+func testPritunlRouteServerConfig(serverName string) string {
+	return fmt.Sprintf(`
+		resource "pritunl_server" "test" {
+			name = "%[1]s"
+		}
+	`, serverName)
+}
+
+func testPritunlRouteConfig(serverName, network, comment string) string {
+	return testPritunlRouteServerConfig(serverName) + fmt.Sprintf(`
+		resource "pritunl_route" "test" {
+			server_id = pritunl_server.test.id
+			network   = "%[1]s"
+			comment   = "%[2]s"
+			nat       = true
+			metric    = 10
+		}
+	`, network, comment)
+}
+
+func testPritunlRouteOnlineServerConfig(serverName, orgName, network, restartPolicy string) string {
+	return fmt.Sprintf(`
+		resource "pritunl_organization" "test" {
+			name = "%[2]s"
+		}
+
+		resource "pritunl_server" "test" {
+			name             = "%[1]s"
+			status           = "online"
+			organization_ids = [pritunl_organization.test.id]
+		}
+
+		resource "pritunl_route" "test" {
+			server_id      = pritunl_server.test.id
+			network        = "%[3]s"
+			restart_policy = "%[4]s"
+		}
+	`, serverName, orgName, network, restartPolicy)
+}
+
+func testPritunlRouteRemoved(network string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		serverId := s.RootModule().Resources["pritunl_server.test"].Primary.ID
+
+		routes, err := testClient.GetRoutesByServer(context.Background(), serverId)
+		if err != nil {
+			return err
+		}
+		for _, route := range routes {
+			if route.Network == network {
+				return fmt.Errorf("a route is not removed")
+			}
+		}
+		return nil
+	}
+}
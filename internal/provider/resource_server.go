@@ -4,15 +4,64 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// serverUpdatableAttributes are the server attributes sent to Pritunl on update
+// when changed. Their names match the JSON keys of pritunl.Server.
+var serverUpdatableAttributes = []string{
+	"name",
+	"protocol",
+	"cipher",
+	"hash",
+	"port",
+	"network",
+	"bind_address",
+	"network_wg",
+	"port_wg",
+	"sso_auth",
+	"otp_auth",
+	"device_auth",
+	"dynamic_firewall",
+	"ipv6",
+	"dh_param_bits",
+	"ping_interval",
+	"ping_timeout",
+	"link_ping_interval",
+	"link_ping_timeout",
+	"session_timeout",
+	"inactive_timeout",
+	"max_clients",
+	"network_mode",
+	"network_start",
+	"network_end",
+	"mss_fix",
+	"max_devices",
+	"pre_connect_msg",
+	"allowed_devices",
+	"search_domain",
+	"replica_count",
+	"multi_device",
+	"debug",
+	"restrict_routes",
+	"block_outside_dns",
+	"dns_mapping",
+	"inter_client",
+	"vxlan",
+	"geo_sort",
+	"groups",
+	"dns_servers",
+}
+
 func resourceServer() *schema.Resource {
 	return &schema.Resource{
 		Description: "The organization resource allows managing information about a particular Pritunl server.",
@@ -415,7 +464,8 @@ func resourceServer() *schema.Resource {
 				},
 				Required:    false,
 				Optional:    true,
-				Description: "The list of attached organizations to the server.",
+				Computed:    true,
+				Description: "The list of attached organizations to the server. When it isn't set, the organizations attached with `pritunl_server_organization_attachment` are kept.",
 			},
 			"host_ids": {
 				Type: schema.TypeList,
@@ -459,11 +509,69 @@ func resourceServer() *schema.Resource {
 							Description: "Net Gateway vpn traffic destined to this network",
 							Computed:    true,
 						},
+						"nat_interface": {
+							Type:        schema.TypeString,
+							Required:    false,
+							Optional:    true,
+							Description: "Interface used for NAT, leave blank to detect the interface automatically",
+						},
+						"nat_netmap": {
+							Type:        schema.TypeString,
+							Required:    false,
+							Optional:    true,
+							Description: "Network address with subnet to map the routed network to with NAT netmap",
+							ValidateFunc: func(i interface{}, s string) ([]string, []error) {
+								return validation.IsCIDR(i, s)
+							},
+						},
+						"metric": {
+							Type:         schema.TypeInt,
+							Required:     false,
+							Optional:     true,
+							Description:  "Route metric, leave blank to use the default metric",
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"advertise": {
+							Type:        schema.TypeBool,
+							Required:    false,
+							Optional:    true,
+							Description: "Advertise the route to the VPC route table of the cloud provider",
+						},
+						"vpc_id": {
+							Type:        schema.TypeString,
+							Required:    false,
+							Optional:    true,
+							Description: "The ID of the VPC the route is advertised to",
+						},
+						"vpc_region": {
+							Type:        schema.TypeString,
+							Required:    false,
+							Optional:    true,
+							Description: "The region of the VPC the route is advertised to",
+						},
+						"network_link": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Shows if the route is created from a user network link",
+						},
+						"server_link": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Shows if the route is created from a linked server",
+						},
 					},
 				},
 				Required:    false,
 				Optional:    true,
-				Description: "The list of attached routes to the server",
+				Computed:    true,
+				Description: "The list of attached routes to the server. When no route block is set, the routes added with `pritunl_route` are kept.",
+			},
+			"restart_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      serverRestartPolicyAuto,
+				Description:  "Defines how an online server is restarted when a change can't be applied while it's online. `auto` stops the server and starts it again, `never` fails the plan and the apply instead, `restart_operation` applies the changes online and restarts the server with the Pritunl restart operation, falling back to stop and start for the changes Pritunl rejects on an online server.",
+				ValidateFunc: validation.StringInSlice([]string{serverRestartPolicyAuto, serverRestartPolicyNever, serverRestartPolicyRestartOperation}, false),
 			},
 			"status": {
 				Type:         schema.TypeString,
@@ -498,6 +606,12 @@ func resourceServer() *schema.Resource {
 				},
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		CustomizeDiff: resourceServerCustomizeDiff,
 		CreateContext: resourceCreateServer,
 		ReadContext:   resourceReadServer,
 		UpdateContext: resourceUpdateServer,
@@ -508,29 +622,58 @@ func resourceServer() *schema.Resource {
 	}
 }
 
+// resourceServerCustomizeDiff warns in the plan logs when applying the changes
+// restarts an online server, or fails the plan if restart_policy forbids it.
+func resourceServerCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	oldStatus, newStatus := d.GetChange("status")
+	if oldStatus.(string) != pritunl.ServerStatusOnline || newStatus.(string) == pritunl.ServerStatusOffline {
+		return nil
+	}
+
+	if changes := serverRestartRequiredChanges(d); len(changes) > 0 {
+		if d.Get("restart_policy").(string) == serverRestartPolicyNever {
+			return fmt.Errorf("changes of %s require a restart of the online server %s, which is forbidden by restart_policy = %s", strings.Join(changes, ", "), d.Get("name"), serverRestartPolicyNever)
+		}
+
+		tflog.Warn(ctx, "applying the changes restarts the online server, its VPN clients will be disconnected", map[string]interface{}{"id": d.Id(), "name": d.Get("name"), "attributes": changes})
+	}
+
+	return nil
+}
+
 // Uses for importing
 func resourceReadServer(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(pritunl.Client)
 
-	server, err := apiClient.GetServer(d.Id())
+	server, err := apiClient.GetServer(ctx, d.Id())
 	if err != nil {
+		if !d.IsNewResource() && pritunl.IsNotFound(err) {
+			tflog.Warn(ctx, "server not found, removing from state", map[string]interface{}{"id": d.Id()})
+			d.SetId("")
+			return nil
+		}
+
 		return diag.FromErr(err)
 	}
 
 	// get organizations
-	organizations, err := apiClient.GetOrganizationsByServer(d.Id())
+	organizations, err := apiClient.GetOrganizationsByServer(ctx, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	// get routes
-	routes, err := apiClient.GetRoutesByServer(d.Id())
+	routes, err := apiClient.GetRoutesByServer(ctx, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	// get hosts
-	hosts, err := apiClient.GetHostsByServer(d.Id())
+	hosts, err := apiClient.GetHostsByServer(ctx, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -577,6 +720,11 @@ func resourceReadServer(ctx context.Context, d *schema.ResourceData, meta interf
 	d.Set("geo_sort", server.GeoSort)
 	d.Set("status", server.Status)
 
+	// restart_policy isn't stored in Pritunl, set the default one on import
+	if _, ok := d.GetOk("restart_policy"); !ok {
+		d.Set("restart_policy", serverRestartPolicyAuto)
+	}
+
 	if len(organizations) > 0 {
 		organizationsList := make([]string, 0)
 
@@ -701,7 +849,7 @@ func resourceCreateServer(ctx context.Context, d *schema.ResourceData, meta inte
 		"geo_sort":           d.Get("geo_sort"),
 	}
 
-	server, err := apiClient.CreateServer(serverData)
+	server, err := apiClient.CreateServer(ctx, serverData)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -711,7 +859,7 @@ func resourceCreateServer(ctx context.Context, d *schema.ResourceData, meta inte
 	if d.HasChange("organization_ids") {
 		_, newOrgs := d.GetChange("organization_ids")
 		for _, v := range newOrgs.([]interface{}) {
-			err = apiClient.AttachOrganizationToServer(v.(string), d.Id())
+			err = apiClient.AttachOrganizationToServer(ctx, v.(string), d.Id())
 			if err != nil {
 				return diag.Errorf("Error on attaching server to the organization: %s", err)
 			}
@@ -724,7 +872,7 @@ func resourceCreateServer(ctx context.Context, d *schema.ResourceData, meta inte
 		{Network: "8.8.8.8/32", Nat: true}, // DNS Server route added in newer Pritunl versions
 	}
 	for _, defaultRoute := range defaultRoutes {
-		err = apiClient.DeleteRouteFromServer(d.Id(), defaultRoute)
+		err = apiClient.DeleteRouteFromServer(ctx, d.Id(), defaultRoute)
 		if err != nil {
 			return diag.Errorf("Error on deleting default route from the server: %s", err)
 		}
@@ -738,7 +886,7 @@ func resourceCreateServer(ctx context.Context, d *schema.ResourceData, meta inte
 			routes = append(routes, pritunl.ConvertMapToRoute(v.(map[string]interface{})))
 		}
 
-		err = apiClient.AddRoutesToServer(d.Id(), routes)
+		err = apiClient.AddRoutesToServer(ctx, d.Id(), routes)
 		if err != nil {
 			return diag.Errorf("Error on attaching route from the server: %s", err)
 		}
@@ -747,12 +895,12 @@ func resourceCreateServer(ctx context.Context, d *schema.ResourceData, meta inte
 	if d.HasChange("host_ids") {
 		// delete default host(s) only when host_ids aren't empty
 
-		hosts, err := apiClient.GetHostsByServer(d.Id())
+		hosts, err := apiClient.GetHostsByServer(ctx, d.Id())
 		if err != nil {
 			return diag.FromErr(err)
 		}
 		for _, host := range hosts {
-			err = apiClient.DetachHostFromServer(host.ID, d.Id())
+			err = apiClient.DetachHostFromServer(ctx, host.ID, d.Id())
 			if err != nil {
 				return diag.Errorf("Error on detaching a host from the server: %s", err)
 			}
@@ -760,7 +908,7 @@ func resourceCreateServer(ctx context.Context, d *schema.ResourceData, meta inte
 
 		_, newHosts := d.GetChange("host_ids")
 		for _, v := range newHosts.([]interface{}) {
-			err = apiClient.AttachHostToServer(v.(string), d.Id())
+			err = apiClient.AttachHostToServer(ctx, v.(string), d.Id())
 			if err != nil {
 				return diag.Errorf("Error on attaching a host to the server: %s", err)
 			}
@@ -768,10 +916,15 @@ func resourceCreateServer(ctx context.Context, d *schema.ResourceData, meta inte
 	}
 
 	if d.Get("status").(string) == pritunl.ServerStatusOnline {
-		err = apiClient.StartServer(d.Id())
+		err = apiClient.StartServer(ctx, d.Id())
 		if err != nil {
 			return diag.Errorf("Error on starting server: %s", err)
 		}
+
+		err = waitForServerStatus(ctx, apiClient, d.Id(), pritunl.ServerStatusOnline, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return resourceReadServer(ctx, d, meta)
@@ -780,35 +933,40 @@ func resourceCreateServer(ctx context.Context, d *schema.ResourceData, meta inte
 func resourceUpdateServer(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(pritunl.Client)
 
-	server, err := apiClient.GetServer(d.Id())
+	// hold the lock from the first read of the server status, so attachments
+	// can't stop or start the server in between
+	serverLocks.Lock(d.Id())
+	defer serverLocks.Unlock(d.Id())
+
+	server, err := apiClient.GetServer(ctx, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	prevServerStatus := server.Status
 
-	if v, ok := d.GetOk("name"); ok {
-		server.Name = v.(string)
+	if d.HasChange("name") {
+		server.Name = d.Get("name").(string)
 	}
 
-	if v, ok := d.GetOk("protocol"); ok {
-		server.Protocol = v.(string)
+	if d.HasChange("protocol") {
+		server.Protocol = d.Get("protocol").(string)
 	}
 
-	if v, ok := d.GetOk("cipher"); ok {
-		server.Cipher = v.(string)
+	if d.HasChange("cipher") {
+		server.Cipher = d.Get("cipher").(string)
 	}
 
-	if v, ok := d.GetOk("hash"); ok {
-		server.Hash = v.(string)
+	if d.HasChange("hash") {
+		server.Hash = d.Get("hash").(string)
 	}
 
-	if v, ok := d.GetOk("port"); ok {
-		server.Port = v.(int)
+	if d.HasChange("port") {
+		server.Port = d.Get("port").(int)
 	}
 
-	if v, ok := d.GetOk("network"); ok {
-		server.Network = v.(string)
+	if d.HasChange("network") {
+		server.Network = d.Get("network").(string)
 	}
 
 	if d.HasChange("bind_address") {
@@ -938,6 +1096,10 @@ func resourceUpdateServer(ctx context.Context, d *schema.ResourceData, meta inte
 		server.DnsMapping = d.Get("dns_mapping").(bool)
 	}
 
+	if d.HasChange("inter_client") {
+		server.InterClient = d.Get("inter_client").(bool)
+	}
+
 	if d.HasChange("vxlan") {
 		server.VxLan = d.Get("vxlan").(bool)
 	}
@@ -962,10 +1124,62 @@ func resourceUpdateServer(ctx context.Context, d *schema.ResourceData, meta inte
 		server.DnsServers = dnsServers
 	}
 
-	// Stop server before applying any change
-	err = apiClient.StopServer(d.Id())
-	if err != nil {
-		return diag.Errorf("Error on stopping server: %s", err)
+	// only the changed attributes are sent, so they can be set to false or zero values
+	fields := make([]string, 0)
+	for _, key := range serverUpdatableAttributes {
+		if d.HasChange(key) {
+			fields = append(fields, key)
+		}
+	}
+	if d.HasChanges("network_wg", "port_wg") {
+		fields = append(fields, "wg")
+	}
+
+	// Start server if it was ONLINE before and status wasn't changed OR status was changed to ONLINE
+	shouldServerBeStarted := (prevServerStatus == pritunl.ServerStatusOnline && !d.HasChange("status")) || (d.HasChange("status") && d.Get("status").(string) != pritunl.ServerStatusOffline)
+
+	// Stop an online server only when a change can't be applied while it's online
+	restartPolicy := d.Get("restart_policy").(string)
+	restartRequiredChanges := serverRestartRequiredChanges(d)
+	isServerStopped := prevServerStatus != pritunl.ServerStatusOnline
+	stopServer := func() error {
+		// the policy only forbids restarts, stopping the server on request is allowed
+		if restartPolicy == serverRestartPolicyNever && shouldServerBeStarted {
+			return fmt.Errorf("changes of %s require a restart of the online server, which is forbidden by restart_policy = %s", strings.Join(restartRequiredChanges, ", "), serverRestartPolicyNever)
+		}
+
+		tflog.Info(ctx, "stopping server to apply changes", map[string]interface{}{"id": d.Id(), "attributes": restartRequiredChanges})
+
+		err := apiClient.StopServer(ctx, d.Id())
+		if err != nil {
+			return fmt.Errorf("Error on stopping server: %s", err)
+		}
+		isServerStopped = true
+
+		return waitForServerStatus(ctx, apiClient, d.Id(), pritunl.ServerStatusOffline, d.Timeout(schema.TimeoutUpdate))
+	}
+
+	// applyChange retries a change rejected by Pritunl on the online server after stopping it
+	applyChange := func(fn func() error) error {
+		err := fn()
+		if err == nil || isServerStopped || !pritunl.IsServerNotOffline(err) {
+			return err
+		}
+
+		if len(restartRequiredChanges) == 0 {
+			restartRequiredChanges = fields
+		}
+		if err = stopServer(); err != nil {
+			return err
+		}
+
+		return fn()
+	}
+
+	if !isServerStopped && (!shouldServerBeStarted || (len(restartRequiredChanges) > 0 && restartPolicy != serverRestartPolicyRestartOperation)) {
+		if err = stopServer(); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	if d.HasChange("organization_ids") {
@@ -973,7 +1187,7 @@ func resourceUpdateServer(ctx context.Context, d *schema.ResourceData, meta inte
 
 		oldOrgsOnly := diffStringLists(oldOrgs.([]interface{}), newOrgs.([]interface{}))
 		for _, v := range oldOrgsOnly {
-			err = apiClient.DetachOrganizationFromServer(v, d.Id())
+			err = applyChange(func() error { return apiClient.DetachOrganizationFromServer(ctx, v, d.Id()) })
 			if err != nil {
 				return diag.Errorf("Error on detaching server to the organization: %s", err)
 			}
@@ -981,7 +1195,7 @@ func resourceUpdateServer(ctx context.Context, d *schema.ResourceData, meta inte
 
 		newOrgsOnly := diffStringLists(newOrgs.([]interface{}), oldOrgs.([]interface{}))
 		for _, v := range newOrgsOnly {
-			err = apiClient.AttachOrganizationToServer(v, d.Id())
+			err = applyChange(func() error { return apiClient.AttachOrganizationToServer(ctx, v, d.Id()) })
 			if err != nil {
 				return diag.Errorf("Error on attaching server to the organization: %s", err)
 			}
@@ -1005,15 +1219,15 @@ func resourceUpdateServer(ctx context.Context, d *schema.ResourceData, meta inte
 		for network, newRoute := range newRoutesMap {
 			if oldRoute, found := oldRoutesMap[network]; found {
 				// update if something changed or skip
-				if oldRoute.Nat != newRoute.Nat || oldRoute.NetGateway != newRoute.NetGateway || oldRoute.Comment != newRoute.Comment {
-					err = apiClient.UpdateRouteOnServer(d.Id(), newRoute)
+				if oldRoute != newRoute {
+					err = applyChange(func() error { return apiClient.UpdateRouteOnServer(ctx, d.Id(), newRoute) })
 					if err != nil {
 						return diag.Errorf("Error on updating route on the server: %s", err)
 					}
 				}
 			} else {
 				// add route
-				err = apiClient.AddRouteToServer(d.Id(), newRoute)
+				err = applyChange(func() error { return apiClient.AddRouteToServer(ctx, d.Id(), newRoute) })
 				if err != nil {
 					return diag.Errorf("Error on adding route to the server: %s", err)
 				}
@@ -1023,7 +1237,7 @@ func resourceUpdateServer(ctx context.Context, d *schema.ResourceData, meta inte
 		for network, oldRoute := range oldRoutesMap {
 			if _, found := newRoutesMap[network]; !found {
 				// delete route
-				err = apiClient.DeleteRouteFromServer(d.Id(), oldRoute)
+				err = applyChange(func() error { return apiClient.DeleteRouteFromServer(ctx, d.Id(), oldRoute) })
 				if err != nil {
 					return diag.Errorf("Error on deleting route from the server: %s", err)
 				}
@@ -1033,45 +1247,80 @@ func resourceUpdateServer(ctx context.Context, d *schema.ResourceData, meta inte
 
 	if d.HasChange("host_ids") {
 		oldHosts, newHosts := d.GetChange("host_ids")
-		for _, v := range oldHosts.([]interface{}) {
-			err = apiClient.DetachHostFromServer(v.(string), d.Id())
+
+		oldHostsOnly := diffStringLists(oldHosts.([]interface{}), newHosts.([]interface{}))
+		for _, v := range oldHostsOnly {
+			err = applyChange(func() error { return apiClient.DetachHostFromServer(ctx, v, d.Id()) })
 			if err != nil {
-				return diag.Errorf("Error on detaching server to the organization: %s", err)
+				return diag.Errorf("Error on detaching a host from the server: %s", err)
 			}
 		}
-		for _, v := range newHosts.([]interface{}) {
-			err = apiClient.AttachHostToServer(v.(string), d.Id())
+
+		newHostsOnly := diffStringLists(newHosts.([]interface{}), oldHosts.([]interface{}))
+		for _, v := range newHostsOnly {
+			err = applyChange(func() error { return apiClient.AttachHostToServer(ctx, v, d.Id()) })
 			if err != nil {
-				return diag.Errorf("Error on attaching server to the organization: %s", err)
+				return diag.Errorf("Error on attaching a host to the server: %s", err)
 			}
 		}
 	}
 
-	// Start server if it was ONLINE before and status wasn't changed OR status was changed to ONLINE
-	shouldServerBeStarted := (prevServerStatus == pritunl.ServerStatusOnline && !d.HasChange("status")) || (d.HasChange("status") && d.Get("status").(string) != pritunl.ServerStatusOffline)
-
-	err = apiClient.UpdateServer(d.Id(), server)
-	if err != nil {
-		// start server in case of error?
-		return diag.FromErr(err)
+	if len(fields) > 0 {
+		err = applyChange(func() error { return apiClient.UpdateServer(ctx, d.Id(), server, fields...) })
+		if err != nil {
+			// start server in case of error?
+			return diag.FromErr(err)
+		}
 	}
 
-	if shouldServerBeStarted {
-		err = apiClient.StartServer(d.Id())
+	var diags diag.Diagnostics
+
+	if shouldServerBeStarted && isServerStopped {
+		err = apiClient.StartServer(ctx, d.Id())
 		if err != nil {
 			return diag.Errorf("Error on starting server: %s", err)
 		}
+
+		err = waitForServerStatus(ctx, apiClient, d.Id(), pritunl.ServerStatusOnline, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if prevServerStatus == pritunl.ServerStatusOnline {
+			diags = append(diags, serverRestartedWarning(server.Name, restartRequiredChanges))
+		}
+	} else if shouldServerBeStarted && len(restartRequiredChanges) > 0 && restartPolicy == serverRestartPolicyRestartOperation {
+		tflog.Info(ctx, "restarting server to apply changes", map[string]interface{}{"id": d.Id(), "attributes": restartRequiredChanges})
+
+		err = apiClient.RestartServer(ctx, d.Id())
+		if err != nil {
+			return diag.Errorf("Error on restarting server: %s", err)
+		}
+
+		err = waitForServerStatus(ctx, apiClient, d.Id(), pritunl.ServerStatusOnline, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		diags = append(diags, serverRestartedWarning(server.Name, restartRequiredChanges))
 	}
 
-	return resourceReadServer(ctx, d, meta)
+	return append(diags, resourceReadServer(ctx, d, meta)...)
 }
 
 func resourceDeleteServer(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(pritunl.Client)
 
-	err := apiClient.DeleteServer(d.Id())
+	err := apiClient.DeleteServer(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		if !pritunl.IsNotFound(err) {
+			return diag.FromErr(err)
+		}
+	} else {
+		err = waitForServerDeleted(ctx, apiClient, d.Id(), d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	d.SetId("")
@@ -1117,6 +1366,16 @@ func flattenRoutesData(routesList []pritunl.Route) []interface{} {
 			if route.Comment != "" {
 				routeMap["comment"] = route.Comment
 			}
+			routeMap["nat_interface"] = route.NatInterface
+			routeMap["nat_netmap"] = route.NatNetmap
+			routeMap["advertise"] = route.Advertise
+			routeMap["vpc_id"] = route.VpcID
+			routeMap["vpc_region"] = route.VpcRegion
+			routeMap["network_link"] = route.NetworkLink
+			routeMap["server_link"] = route.ServerLink
+			if metric, err := strconv.Atoi(route.Metric); err == nil {
+				routeMap["metric"] = metric
+			}
 
 			routes = append(routes, routeMap)
 		}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceServerHostAttachment() *schema.Resource {
+	return &schema.Resource{
+		Description: "The server host attachment resource allows attaching a Pritunl host to a server. An online server is restarted to attach or detach a host, with `restart_policy = \"never\"` the apply fails instead. Use it instead of `host_ids` of the `pritunl_server`, a server with `host_ids` set detaches the hosts attached here on its next apply.",
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the server.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"host_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the host attached to the server.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"restart_policy": serverChangeRestartPolicySchema(),
+		},
+		CreateContext: resourceServerHostAttachmentCreate,
+		ReadContext:   resourceServerHostAttachmentRead,
+		UpdateContext: resourceServerHostAttachmentUpdate,
+		DeleteContext: resourceServerHostAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServerHostAttachmentImport,
+		},
+	}
+}
+
+func resourceServerHostAttachmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(pritunl.Client)
+
+	serverId := d.Get("server_id").(string)
+	hostId := d.Get("host_id").(string)
+
+	hosts, err := apiClient.GetHostsByServer(ctx, serverId)
+	if err != nil {
+		if !d.IsNewResource() && pritunl.IsNotFound(err) {
+			tflog.Warn(ctx, "server of the host attachment not found, removing from state", map[string]interface{}{"id": d.Id()})
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	for _, host := range hosts {
+		if host.ID == hostId {
+			return nil
+		}
+	}
+
+	if d.IsNewResource() {
+		return diag.Errorf("host %s is not attached to the server %s", hostId, serverId)
+	}
+
+	tflog.Warn(ctx, "host is not attached to the server, removing from state", map[string]interface{}{"id": d.Id()})
+	d.SetId("")
+
+	return nil
+}
+
+func resourceServerHostAttachmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(pritunl.Client)
+
+	serverId := d.Get("server_id").(string)
+	hostId := d.Get("host_id").(string)
+
+	err := withServerStopped(ctx, apiClient, serverId, d.Get("restart_policy").(string), func() error {
+		return apiClient.AttachHostToServer(ctx, hostId, serverId)
+	})
+	if err != nil {
+		return diag.Errorf("Error on attaching a host to the server: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", serverId, hostId))
+
+	return resourceServerHostAttachmentRead(ctx, d, meta)
+}
+
+// resourceServerHostAttachmentUpdate only stores restart_policy, the other
+// attributes force a new attachment.
+func resourceServerHostAttachmentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceServerHostAttachmentRead(ctx, d, meta)
+}
+
+func resourceServerHostAttachmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(pritunl.Client)
+
+	serverId := d.Get("server_id").(string)
+	hostId := d.Get("host_id").(string)
+
+	err := withServerStopped(ctx, apiClient, serverId, d.Get("restart_policy").(string), func() error {
+		return apiClient.DetachHostFromServer(ctx, hostId, serverId)
+	})
+	if err != nil && !pritunl.IsNotFound(err) {
+		return diag.Errorf("Error on detaching a host from the server: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceServerHostAttachmentImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.Split(d.Id(), "/")
+	if len(attributes) != 2 || attributes[0] == "" || attributes[1] == "" {
+		return nil, fmt.Errorf("invalid format: expected ${serverId}/${hostId}, e.g. 60cd0be07723cf3c9114686c/60cd0be17723cf3c91146875, actual id is %s", d.Id())
+	}
+
+	d.Set("server_id", attributes[0])
+	d.Set("host_id", attributes[1])
+
+	d.Set("restart_policy", serverRestartPolicyAuto)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPritunlServerHostAttachment(t *testing.T) {
+
+	t.Run("attaches a host to a server without error", func(t *testing.T) {
+		serverName := "tfacc-server1"
+		// pritunl.local sets in Makefile's "test" target
+		hostname := "pritunl.local"
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				// Pritunl attaches the host to new servers, detach it first
+				{
+					Config: testPritunlServerHostAttachmentServerConfig(serverName, hostname),
+					Check:  testPritunlServerDetachHosts,
+				},
+				{
+					Config: testPritunlServerHostAttachmentConfig(serverName, hostname),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrPair("pritunl_server_host_attachment.test", "server_id", "pritunl_server.test", "id"),
+						resource.TestCheckResourceAttrPair("pritunl_server_host_attachment.test", "host_id", "data.pritunl_host.test", "id"),
+						testPritunlServerHostAttached(true),
+					),
+				},
+				// import test
+				importStep("pritunl_server_host_attachment.test"),
+				// the host is detached while the server still exists
+				{
+					Config: testPritunlServerHostAttachmentServerConfig(serverName, hostname),
+					Check:  testPritunlServerHostAttached(false),
+				},
+			},
+		})
+	})
+}
+
+func testPritunlServerHostAttachmentServerConfig(serverName, hostname string) string {
+	return fmt.Sprintf(`
+		data "pritunl_host" "test" {
+			hostname = "%[2]s"
+		}
+
+		resource "pritunl_server" "test" {
+			name = "%[1]s"
+		}
+	`, serverName, hostname)
+}
+
+func testPritunlServerHostAttachmentConfig(serverName, hostname string) string {
+	return testPritunlServerHostAttachmentServerConfig(serverName, hostname) + `
+		resource "pritunl_server_host_attachment" "test" {
+			server_id = pritunl_server.test.id
+			host_id   = data.pritunl_host.test.id
+		}
+	`
+}
+
+func testPritunlServerDetachHosts(s *terraform.State) error {
+	serverId := s.RootModule().Resources["pritunl_server.test"].Primary.ID
+
+	hosts, err := testClient.GetHostsByServer(context.Background(), serverId)
+	if err != nil {
+		return err
+	}
+	for _, host := range hosts {
+		err = testClient.DetachHostFromServer(context.Background(), host.ID, serverId)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func testPritunlServerHostAttached(attached bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		serverId := s.RootModule().Resources["pritunl_server.test"].Primary.ID
+		hostId := s.RootModule().Resources["data.pritunl_host.test"].Primary.ID
+
+		hosts, err := testClient.GetHostsByServer(context.Background(), serverId)
+		if err != nil {
+			return err
+		}
+		for _, host := range hosts {
+			if host.ID == hostId {
+				if !attached {
+					return fmt.Errorf("a host is not detached")
+				}
+				return nil
+			}
+		}
+		if attached {
+			return fmt.Errorf("a host is not attached")
+		}
+		return nil
+	}
+}
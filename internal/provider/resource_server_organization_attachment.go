@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceServerOrganizationAttachment() *schema.Resource {
+	return &schema.Resource{
+		Description: "The server organization attachment resource allows attaching a Pritunl organization to a server. An online server is restarted to attach or detach an organization, with `restart_policy = \"never\"` the apply fails instead. The attached organizations show up in `organization_ids` of the `pritunl_server`, so leave that attribute unset, a list there detaches the organizations missing from it.",
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the server.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"organization_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the organization attached to the server.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"restart_policy": serverChangeRestartPolicySchema(),
+		},
+		CreateContext: resourceServerOrganizationAttachmentCreate,
+		ReadContext:   resourceServerOrganizationAttachmentRead,
+		UpdateContext: resourceServerOrganizationAttachmentUpdate,
+		DeleteContext: resourceServerOrganizationAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServerOrganizationAttachmentImport,
+		},
+	}
+}
+
+func resourceServerOrganizationAttachmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(pritunl.Client)
+
+	serverId := d.Get("server_id").(string)
+	organizationId := d.Get("organization_id").(string)
+
+	organizations, err := apiClient.GetOrganizationsByServer(ctx, serverId)
+	if err != nil {
+		if !d.IsNewResource() && pritunl.IsNotFound(err) {
+			tflog.Warn(ctx, "server of the organization attachment not found, removing from state", map[string]interface{}{"id": d.Id()})
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	for _, organization := range organizations {
+		if organization.ID == organizationId {
+			return nil
+		}
+	}
+
+	if d.IsNewResource() {
+		return diag.Errorf("organization %s is not attached to the server %s", organizationId, serverId)
+	}
+
+	tflog.Warn(ctx, "organization is not attached to the server, removing from state", map[string]interface{}{"id": d.Id()})
+	d.SetId("")
+
+	return nil
+}
+
+func resourceServerOrganizationAttachmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(pritunl.Client)
+
+	serverId := d.Get("server_id").(string)
+	organizationId := d.Get("organization_id").(string)
+
+	err := withServerStopped(ctx, apiClient, serverId, d.Get("restart_policy").(string), func() error {
+		return apiClient.AttachOrganizationToServer(ctx, organizationId, serverId)
+	})
+	if err != nil {
+		return diag.Errorf("Error on attaching server to the organization: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", serverId, organizationId))
+
+	return resourceServerOrganizationAttachmentRead(ctx, d, meta)
+}
+
+// resourceServerOrganizationAttachmentUpdate only stores restart_policy, the other
+// attributes force a new attachment.
+func resourceServerOrganizationAttachmentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceServerOrganizationAttachmentRead(ctx, d, meta)
+}
+
+func resourceServerOrganizationAttachmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(pritunl.Client)
+
+	serverId := d.Get("server_id").(string)
+	organizationId := d.Get("organization_id").(string)
+
+	err := withServerStopped(ctx, apiClient, serverId, d.Get("restart_policy").(string), func() error {
+		return apiClient.DetachOrganizationFromServer(ctx, organizationId, serverId)
+	})
+	if err != nil && !pritunl.IsNotFound(err) {
+		return diag.Errorf("Error on detaching server from the organization: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceServerOrganizationAttachmentImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.Split(d.Id(), "/")
+	if len(attributes) != 2 || attributes[0] == "" || attributes[1] == "" {
+		return nil, fmt.Errorf("invalid format: expected ${serverId}/${organizationId}, e.g. 60cd0be07723cf3c9114686c/60cd0be17723cf3c91146873, actual id is %s", d.Id())
+	}
+
+	d.Set("server_id", attributes[0])
+	d.Set("organization_id", attributes[1])
+
+	d.Set("restart_policy", serverRestartPolicyAuto)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPritunlServerOrganizationAttachment(t *testing.T) {
+
+	t.Run("attaches an organization to a server without error", func(t *testing.T) {
+		serverName := "tfacc-server1"
+		orgName := "tfacc-org1"
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			CheckDestroy:      testPritunlServerOrganizationAttachmentDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testPritunlServerOrganizationAttachmentConfig(serverName, orgName),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrPair("pritunl_server_organization_attachment.test", "server_id", "pritunl_server.test", "id"),
+						resource.TestCheckResourceAttrPair("pritunl_server_organization_attachment.test", "organization_id", "pritunl_organization.test", "id"),
+					),
+				},
+				// import test
+				importStep("pritunl_server_organization_attachment.test"),
+			},
+		})
+	})
+}
+
+func testPritunlServerOrganizationAttachmentConfig(serverName, orgName string) string {
+	return fmt.Sprintf(`
+		resource "pritunl_organization" "test" {
+			name = "%[2]s"
+		}
+
+		resource "pritunl_server" "test" {
+			name = "%[1]s"
+		}
+
+		resource "pritunl_server_organization_attachment" "test" {
+			server_id       = pritunl_server.test.id
+			organization_id = pritunl_organization.test.id
+		}
+	`, serverName, orgName)
+}
+
+func testPritunlServerOrganizationAttachmentDestroy(s *terraform.State) error {
+	serverId := s.RootModule().Resources["pritunl_server.test"].Primary.ID
+	organizationId := s.RootModule().Resources["pritunl_organization.test"].Primary.ID
+
+	organizations, err := testClient.GetOrganizationsByServer(context.Background(), serverId)
+	if err != nil {
+		// the server is destroyed together with the attachment
+		return nil
+	}
+	for _, organization := range organizations {
+		if organization.ID == organizationId {
+			return fmt.Errorf("an organization is not detached")
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -32,6 +33,37 @@ func TestAccPritunlServer(t *testing.T) {
 		})
 	})
 
+	t.Run("sets the default restart_policy on import", func(t *testing.T) {
+		serverName := "tfacc-server1"
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			CheckDestroy:      testPritunlServerDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testPritunlServerSimpleConfig(serverName),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("pritunl_server.test", "restart_policy", "auto"),
+					),
+				},
+				{
+					ResourceName: "pritunl_server.test",
+					ImportState:  true,
+					ImportStateCheck: func(states []*terraform.InstanceState) error {
+						if len(states) != 1 {
+							return fmt.Errorf("expected 1 imported server, got %d", len(states))
+						}
+						if restartPolicy := states[0].Attributes["restart_policy"]; restartPolicy != "auto" {
+							return fmt.Errorf("expected restart_policy auto, got %q", restartPolicy)
+						}
+						return nil
+					},
+				},
+			},
+		})
+	})
+
 	t.Run("creates a server with sso_auth attribute", func(t *testing.T) {
 		serverName := "tfacc-server1"
 
@@ -420,6 +452,36 @@ func TestAccPritunlServer(t *testing.T) {
 		})
 	})
 
+	t.Run("creates a server with a route using NAT netmap and metric", func(t *testing.T) {
+		serverName := "tfacc-server1"
+		routeNetwork := "10.6.0.0/24"
+		routeNetmap := "10.106.0.0/24"
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			CheckDestroy:      testPritunlServerDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testPritunlServerConfigWithNetmapRoute(serverName, routeNetwork, routeNetmap, 10),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("pritunl_server.test", "route.0.network", routeNetwork),
+						resource.TestCheckResourceAttr("pritunl_server.test", "route.0.nat_netmap", routeNetmap),
+						resource.TestCheckResourceAttr("pritunl_server.test", "route.0.metric", "10"),
+					),
+				},
+				{
+					Config: testPritunlServerConfigWithNetmapRoute(serverName, routeNetwork, routeNetmap, 20),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("pritunl_server.test", "route.0.metric", "20"),
+					),
+				},
+				// import test
+				importStep("pritunl_server.test"),
+			},
+		})
+	})
+
 	t.Run("creates a server with error", func(t *testing.T) {
 		t.Run("due to an invalid network", func(t *testing.T) {
 			serverName := "tfacc-server1"
@@ -568,6 +630,154 @@ func TestAccPritunlServer(t *testing.T) {
 			})
 		})
 	})
+
+	t.Run("updates a server turning attributes off", func(t *testing.T) {
+		serverName := "tfacc-server1"
+
+		check := func(enabled bool) resource.TestCheckFunc {
+			return resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("pritunl_server.test", "debug", strconv.FormatBool(enabled)),
+				resource.TestCheckResourceAttr("pritunl_server.test", "multi_device", strconv.FormatBool(enabled)),
+				resource.TestCheckResourceAttr("pritunl_server.test", "block_outside_dns", strconv.FormatBool(enabled)),
+				resource.TestCheckResourceAttr("pritunl_server.test", "inter_client", strconv.FormatBool(enabled)),
+			)
+		}
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			CheckDestroy:      testPritunlServerDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testPritunlServerConfigWithFlags(serverName, true),
+					Check:  check(true),
+				},
+				{
+					Config: testPritunlServerConfigWithFlags(serverName, false),
+					Check:  check(false),
+				},
+			},
+		})
+	})
+
+	t.Run("updates an online server without restart", func(t *testing.T) {
+		serverName := "tfacc-server1"
+		orgName := "tfacc-org1"
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			CheckDestroy:      testPritunlServerDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testPritunlServerConfigOnlineWithPreConnectMsg(serverName, orgName, "first message"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("pritunl_server.test", "status", "online"),
+						resource.TestCheckResourceAttr("pritunl_server.test", "pre_connect_msg", "first message"),
+					),
+				},
+				{
+					Config: testPritunlServerConfigOnlineWithPreConnectMsg(serverName, orgName, "second message"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("pritunl_server.test", "status", "online"),
+						resource.TestCheckResourceAttr("pritunl_server.test", "pre_connect_msg", "second message"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("fails to restart an online server with restart_policy never", func(t *testing.T) {
+		serverName := "tfacc-server1"
+		orgName := "tfacc-org1"
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			CheckDestroy:      testPritunlServerDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testPritunlServerConfigWithRestartPolicy(serverName, orgName, "online", "never", false),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("pritunl_server.test", "status", "online"),
+						resource.TestCheckResourceAttr("pritunl_server.test", "restart_policy", "never"),
+					),
+				},
+				{
+					Config:      testPritunlServerConfigWithRestartPolicy(serverName, orgName, "online", "never", true),
+					ExpectError: regexp.MustCompile("forbidden by restart_policy = never"),
+				},
+			},
+		})
+	})
+
+	t.Run("stops an online server with restart_policy never", func(t *testing.T) {
+		serverName := "tfacc-server1"
+		orgName := "tfacc-org1"
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			CheckDestroy:      testPritunlServerDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testPritunlServerConfigWithRestartPolicy(serverName, orgName, "online", "never", false),
+					Check:  resource.TestCheckResourceAttr("pritunl_server.test", "status", "online"),
+				},
+				{
+					Config: testPritunlServerConfigWithRestartPolicy(serverName, orgName, "offline", "never", false),
+					Check:  resource.TestCheckResourceAttr("pritunl_server.test", "status", "offline"),
+				},
+				{
+					Config: testPritunlServerConfigWithRestartPolicy(serverName, orgName, "offline", "never", true),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("pritunl_server.test", "status", "offline"),
+						resource.TestCheckResourceAttr("pritunl_server.test", "debug", "true"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("restarts an online server with restart_policy restart_operation", func(t *testing.T) {
+		serverName := "tfacc-server1"
+		orgName := "tfacc-org1"
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			CheckDestroy:      testPritunlServerDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testPritunlServerConfigWithRestartPolicy(serverName, orgName, "online", "restart_operation", false),
+					Check:  resource.TestCheckResourceAttr("pritunl_server.test", "status", "online"),
+				},
+				{
+					Config: testPritunlServerConfigWithRestartPolicy(serverName, orgName, "online", "restart_operation", true),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("pritunl_server.test", "status", "online"),
+						resource.TestCheckResourceAttr("pritunl_server.test", "debug", "true"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("removes a server deleted outside of terraform from state", func(t *testing.T) {
+		serverName := "tfacc-server1"
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config:             testPritunlServerSimpleConfig(serverName),
+					Check:              testPritunlServerDisappears("pritunl_server.test"),
+					ExpectNonEmptyPlan: true,
+				},
+			},
+		})
+	})
 }
 
 func testPritunlServerSimpleConfig(name string) string {
@@ -614,6 +824,53 @@ func testPritunlServerConfigWithGeoSort(name string, geoSort bool) string {
 	`, name, geoSort)
 }
 
+func testPritunlServerConfigWithFlags(name string, enabled bool) string {
+	return fmt.Sprintf(`
+		resource "pritunl_server" "test" {
+			name              = "%[1]s"
+			debug             = %[2]v
+			multi_device      = %[2]v
+			block_outside_dns = %[2]v
+			inter_client      = %[2]v
+		}
+	`, name, enabled)
+}
+
+func testPritunlServerConfigOnlineWithPreConnectMsg(name, organizationName, preConnectMsg string) string {
+	return fmt.Sprintf(`
+		resource "pritunl_organization" "test" {
+			name    = "%[2]s"
+		}
+
+		resource "pritunl_server" "test" {
+			name            = "%[1]s"
+			status          = "online"
+			pre_connect_msg = "%[3]s"
+			organization_ids = [
+				pritunl_organization.test.id
+			]
+		}
+	`, name, organizationName, preConnectMsg)
+}
+
+func testPritunlServerConfigWithRestartPolicy(name, organizationName, status, restartPolicy string, debug bool) string {
+	return fmt.Sprintf(`
+		resource "pritunl_organization" "test" {
+			name    = "%[2]s"
+		}
+
+		resource "pritunl_server" "test" {
+			name           = "%[1]s"
+			status         = "%[3]s"
+			restart_policy = "%[4]s"
+			debug          = %[5]v
+			organization_ids = [
+				pritunl_organization.test.id
+			]
+		}
+	`, name, organizationName, status, restartPolicy, debug)
+}
+
 func testPritunlServerConfigWithAttachedOrganization(name, organizationName string) string {
 	return fmt.Sprintf(`
 		resource "pritunl_organization" "test" {
@@ -686,6 +943,21 @@ func testPritunlServerConfigWithAFewAttachedRoutes(name, route1, route2, route3
 	`, name, route1, route2, route3)
 }
 
+func testPritunlServerConfigWithNetmapRoute(name, route, netmap string, metric int) string {
+	return fmt.Sprintf(`
+		resource "pritunl_server" "test" {
+			name = "%[1]s"
+
+			route {
+				network    = "%[2]s"
+				nat        = true
+				nat_netmap = "%[3]s"
+				metric     = %[4]d
+			}
+		}
+	`, name, route, netmap, metric)
+}
+
 func testGetServerConfigWithNetworkAndPort(name, network string, port int) string {
 	return fmt.Sprintf(`
 		resource "pritunl_server" "test" {
@@ -721,7 +993,7 @@ func testPritunlServerConfigWithGroups(name string, groupName string) string {
 func testPritunlServerDestroy(s *terraform.State) error {
 	serverId := s.RootModule().Resources["pritunl_server.test"].Primary.Attributes["id"]
 
-	servers, err := testClient.GetServers()
+	servers, err := testClient.GetServers(context.Background())
 	if err != nil {
 		return err
 	}
@@ -732,3 +1004,11 @@ func testPritunlServerDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+func testPritunlServerDisappears(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		serverId := s.RootModule().Resources[name].Primary.ID
+
+		return testClient.DeleteServer(context.Background(), serverId)
+	}
+}
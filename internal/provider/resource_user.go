@@ -6,7 +6,10 @@ import (
 	"strings"
 
 	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -29,6 +32,14 @@ func resourceUser() *schema.Resource {
 					return validation.StringIsNotEmpty(i, s)
 				},
 			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "The type of the user, `client` for VPN clients or `server` for users of server to server links. Defaults to `client`.",
+				ValidateFunc: validation.StringInSlice([]string{"client", "server"}, false),
+			},
 			"groups": {
 				Type: schema.TypeList,
 				Elem: &schema.Schema{
@@ -101,33 +112,127 @@ func resourceUser() *schema.Resource {
 				Optional:    true,
 				Description: "Bypass secondary authentication such as the PIN and two-factor authentication. Use for server users that can't provide a two-factor code.",
 			},
+			"yubico_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of the YubiKey of the user, required for the Yubico authentication types.",
+			},
+			"status": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Shows if the user is connected to a server.",
+			},
+			"dns_mapping": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The DNS name of the user when the DNS mapping is enabled on the server.",
+			},
+			"audit": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Shows if the audit log is enabled for the user.",
+			},
+			"device_auth": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Shows if the device authentication is required for the user.",
+			},
+			"otp_auth": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Shows if the two-factor authentication is required for the user.",
+			},
+			"sso": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The single sign-on provider the user authenticated with, empty for local users.",
+			},
 			"pin": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   "The PIN for user authentication.",
+				ConflictsWith: []string{"pin_wo"},
+			},
+			"pin_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				Description:   "The PIN for user authentication. The PIN is never stored in the plan or the state, it's only sent on create and when `pin_wo_version` changes. Requires Terraform 1.11 or later.",
+				ConflictsWith: []string{"pin"},
+				RequiredWith:  []string{"pin_wo_version"},
+			},
+			"pin_wo_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The version of `pin_wo`. Change it to send the new value of `pin_wo`, an empty `pin_wo` removes the PIN.",
+			},
+			"pin_set": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Shows if the user has a PIN set.",
+			},
+			"otp_reset_trigger": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Description: "Arbitrary value, changing it after the user is created generates a new two-factor secret for the user, e.g. during offboarding or after a device loss.",
+			},
+			"otp_secret": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The two-factor authentication secret of the user.",
+			},
+			"otp_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
 				Sensitive:   true,
-				Description: "The PIN for user authentication.",
+				Description: "The otpauth:// provisioning URI of the two-factor authentication secret, used to enroll authenticator apps.",
 			},
 		},
 		CreateContext: resourceUserCreate,
 		ReadContext:   resourceUserRead,
 		UpdateContext: resourceUserUpdate,
 		DeleteContext: resourceUserDelete,
+		CustomizeDiff: customdiff.Sequence(
+			customdiff.ComputedIf("otp_secret", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+				return d.Id() != "" && d.HasChange("otp_reset_trigger")
+			}),
+			customdiff.ComputedIf("otp_uri", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+				return d.Id() != "" && d.HasChanges("otp_reset_trigger", "name")
+			}),
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceUserImport,
 		},
 	}
 }
 
-func resourceUserRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(pritunl.Client)
 
-	user, err := apiClient.GetUser(d.Id(), d.Get("organization_id").(string))
+	user, err := apiClient.GetUser(ctx, d.Id(), d.Get("organization_id").(string))
 	if err != nil {
+		if !d.IsNewResource() && pritunl.IsNotFound(err) {
+			tflog.Warn(ctx, "user not found, removing from state", map[string]interface{}{"id": d.Id()})
+			d.SetId("")
+			return nil
+		}
+
 		return diag.FromErr(err)
 	}
 
 	d.Set("name", user.Name)
+	d.Set("type", user.Type)
 	d.Set("auth_type", user.AuthType)
+	d.Set("yubico_id", user.YubicoID)
+	d.Set("status", user.Status)
+	d.Set("dns_mapping", user.DnsMapping)
+	d.Set("audit", user.Audit)
+	d.Set("device_auth", user.DeviceAuth)
+	d.Set("otp_auth", user.OtpAuth)
+	d.Set("sso", user.SSOName())
 	d.Set("dns_servers", user.DnsServers)
 	d.Set("dns_suffix", user.DnsSuffix)
 	d.Set("disabled", user.Disabled)
@@ -138,6 +243,14 @@ func resourceUserRead(_ context.Context, d *schema.ResourceData, meta interface{
 	d.Set("mac_addresses", user.MacAddresses)
 	d.Set("bypass_secondary", user.BypassSecondary)
 	d.Set("organization_id", user.Organization)
+	d.Set("pin_set", user.Pin != nil && user.Pin.IsSet)
+	d.Set("otp_secret", user.OtpSecret)
+	d.Set("otp_uri", user.OtpUri())
+
+	// the PIN was removed out of band, clear it to show the drift
+	if user.Pin != nil && !user.Pin.IsSet {
+		d.Set("pin", "")
+	}
 
 	if len(user.Groups) > 0 {
 		groupsList := make([]string, 0)
@@ -156,11 +269,11 @@ func resourceUserRead(_ context.Context, d *schema.ResourceData, meta interface{
 	return nil
 }
 
-func resourceUserDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(pritunl.Client)
 
-	err := apiClient.DeleteUser(d.Id(), d.Get("organization_id").(string))
-	if err != nil {
+	err := apiClient.DeleteUser(ctx, d.Id(), d.Get("organization_id").(string))
+	if err != nil && !pritunl.IsNotFound(err) {
 		return diag.FromErr(err)
 	}
 
@@ -172,25 +285,31 @@ func resourceUserDelete(_ context.Context, d *schema.ResourceData, meta interfac
 func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(pritunl.Client)
 
-	user, err := apiClient.GetUser(d.Id(), d.Get("organization_id").(string))
+	user, err := apiClient.GetUser(ctx, d.Id(), d.Get("organization_id").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	// the pin is only sent when it's changed, an empty pin removes it
+	user.Pin = nil
 	if d.HasChange("pin") {
-		if v, ok := d.GetOk("pin"); ok {
-			user.Pin = &pritunl.Pin{Secret: v.(string)}
-		}
+		user.Pin = &pritunl.Pin{Secret: d.Get("pin").(string)}
 	}
 
-	if v, ok := d.GetOk("name"); ok {
-		user.Name = v.(string)
+	if d.HasChange("pin_wo_version") {
+		pinWo, diags := getWriteOnlyString(d, "pin_wo")
+		if diags.HasError() {
+			return diags
+		}
+		user.Pin = &pritunl.Pin{Secret: pinWo}
 	}
 
-	if v, ok := d.GetOk("organization_id"); ok {
-		user.Organization = v.(string)
+	if d.HasChange("name") {
+		user.Name = d.Get("name").(string)
 	}
 
+	user.Organization = d.Get("organization_id").(string)
+
 	if d.HasChange("groups") {
 		groups := make([]string, 0)
 		for _, v := range d.Get("groups").([]interface{}) {
@@ -199,13 +318,12 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 		user.Groups = groups
 	}
 
-	if v, ok := d.GetOk("email"); ok {
-		user.Email = v.(string)
+	if d.HasChange("email") {
+		user.Email = d.Get("email").(string)
 	}
 
-	// TODO: Fixme
-	if v, ok := d.GetOk("disabled"); ok {
-		user.Disabled = v.(bool)
+	if d.HasChange("disabled") {
+		user.Disabled = d.Get("disabled").(bool)
 	}
 
 	if d.HasChange("port_forwarding") {
@@ -224,11 +342,11 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 		user.NetworkLinks = networkLinks
 	}
 
-	if v, ok := d.GetOk("client_to_client"); ok {
-		user.ClientToClient = v.(bool)
+	if d.HasChange("client_to_client") {
+		user.ClientToClient = d.Get("client_to_client").(bool)
 	}
 
-	if v, ok := d.GetOk("auth_type"); ok {
+	if v, ok := d.GetOk("auth_type"); ok && d.HasChange("auth_type") {
 		user.AuthType = v.(string)
 	}
 
@@ -248,23 +366,34 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 		user.DnsServers = dnsServers
 	}
 
-	if v, ok := d.GetOk("dns_suffix"); ok {
-		user.DnsSuffix = v.(string)
+	if d.HasChange("dns_suffix") {
+		user.DnsSuffix = d.Get("dns_suffix").(string)
 	}
 
-	if v, ok := d.GetOk("bypass_secondary"); ok {
-		user.BypassSecondary = v.(bool)
+	if d.HasChange("bypass_secondary") {
+		user.BypassSecondary = d.Get("bypass_secondary").(bool)
 	}
 
-	err = apiClient.UpdateUser(d.Id(), user)
+	if d.HasChange("yubico_id") {
+		user.YubicoID = d.Get("yubico_id").(string)
+	}
+
+	err = apiClient.UpdateUser(ctx, d.Id(), user)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if d.HasChange("otp_reset_trigger") {
+		_, err = apiClient.ResetUserOtpSecret(ctx, d.Id(), user.Organization)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceUserRead(ctx, d, meta)
 }
 
-func resourceUserCreate(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(pritunl.Client)
 
 	dnsServers := make([]string, 0)
@@ -295,7 +424,9 @@ func resourceUserCreate(_ context.Context, d *schema.ResourceData, meta interfac
 	userData := pritunl.User{
 		Name:            d.Get("name").(string),
 		Organization:    d.Get("organization_id").(string),
+		Type:            d.Get("type").(string),
 		AuthType:        d.Get("auth_type").(string),
+		YubicoID:        d.Get("yubico_id").(string),
 		DnsServers:      dnsServers,
 		DnsSuffix:       d.Get("dns_suffix").(string),
 		Disabled:        d.Get("disabled").(bool),
@@ -314,17 +445,27 @@ func resourceUserCreate(_ context.Context, d *schema.ResourceData, meta interfac
 		}
 	}
 
-	user, err := apiClient.CreateUser(userData)
+	pinWo, diags := getWriteOnlyString(d, "pin_wo")
+	if diags.HasError() {
+		return diags
+	}
+	if pinWo != "" {
+		userData.Pin = &pritunl.Pin{
+			Secret: pinWo,
+		}
+	}
+
+	user, err := apiClient.CreateUser(ctx, userData)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	d.SetId(user.ID)
 
-	return nil
+	return resourceUserRead(ctx, d, meta)
 }
 
-func resourceUserImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceUserImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	apiClient := meta.(pritunl.Client)
 
 	attributes := strings.Split(d.Id(), "-")
@@ -338,10 +479,25 @@ func resourceUserImport(_ context.Context, d *schema.ResourceData, meta interfac
 	d.SetId(userId)
 	d.Set("organization_id", orgId)
 
-	_, err := apiClient.GetUser(userId, orgId)
+	_, err := apiClient.GetUser(ctx, userId, orgId)
 	if err != nil {
 		return nil, fmt.Errorf("error on getting user during import: %s", err)
 	}
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// getWriteOnlyString returns the configured value of the write-only string
+// attribute, write-only values are only available in the raw config.
+func getWriteOnlyString(d *schema.ResourceData, key string) (string, diag.Diagnostics) {
+	value, diags := d.GetRawConfigAt(cty.GetAttrPath(key))
+	if diags.HasError() {
+		return "", diags
+	}
+
+	if !value.Type().Equals(cty.String) || value.IsNull() || !value.IsKnown() {
+		return "", nil
+	}
+
+	return value.AsString(), nil
+}
@@ -1,8 +1,11 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
 	"testing"
 )
 
@@ -16,6 +19,8 @@ func TestAccPritunlUser(t *testing.T) {
 			resource.TestCheckResourceAttr("pritunl_user.test", "name", username),
 			resource.TestCheckResourceAttr("pritunl_organization.test", "name", orgName),
 			resource.TestCheckNoResourceAttr("pritunl_user.test", "pin"),
+			resource.TestCheckResourceAttr("pritunl_user.test", "type", "client"),
+			resource.TestCheckResourceAttr("pritunl_user.test", "status", "false"),
 		)
 
 		resource.Test(t, resource.TestCase{
@@ -39,6 +44,7 @@ func TestAccPritunlUser(t *testing.T) {
 		check := resource.ComposeTestCheckFunc(
 			resource.TestCheckResourceAttr("pritunl_user.test", "name", username),
 			resource.TestCheckResourceAttr("pritunl_user.test", "pin", pin),
+			resource.TestCheckResourceAttr("pritunl_user.test", "pin_set", "true"),
 			resource.TestCheckResourceAttr("pritunl_organization.test", "name", orgName),
 		)
 
@@ -55,6 +61,147 @@ func TestAccPritunlUser(t *testing.T) {
 			},
 		})
 	})
+	t.Run("sets and removes write-only PIN without error", func(t *testing.T) {
+		username := "tfacc-user5"
+		orgName := "tfacc-org5"
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: testPritunlUserConfigWithWriteOnlyPin(username, orgName, "123456", 1),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckNoResourceAttr("pritunl_user.test", "pin_wo"),
+						resource.TestCheckResourceAttr("pritunl_user.test", "pin_wo_version", "1"),
+						resource.TestCheckResourceAttr("pritunl_user.test", "pin_set", "true"),
+					),
+				},
+				{
+					Config: testPritunlUserConfigWithWriteOnlyPin(username, orgName, "", 2),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("pritunl_user.test", "pin_wo_version", "2"),
+						resource.TestCheckResourceAttr("pritunl_user.test", "pin_set", "false"),
+					),
+				},
+			},
+		})
+	})
+	t.Run("resets OTP secret without error", func(t *testing.T) {
+		username := "tfacc-user6"
+		orgName := "tfacc-org6"
+
+		var otpSecret string
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: testPritunlUserConfigWithOtpResetTrigger(username, orgName, "initial"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrWith("pritunl_user.test", "otp_secret", func(value string) error {
+							otpSecret = value
+							if value == "" {
+								return fmt.Errorf("otp_secret is empty")
+							}
+							return nil
+						}),
+						resource.TestMatchResourceAttr("pritunl_user.test", "otp_uri", regexp.MustCompile(fmt.Sprintf("^otpauth://totp/%s@%s\\?secret=", username, orgName))),
+					),
+				},
+				{
+					Config: testPritunlUserConfigWithOtpResetTrigger(username, orgName, "rotated"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrWith("pritunl_user.test", "otp_secret", func(value string) error {
+							if value == "" || value == otpSecret {
+								return fmt.Errorf("otp_secret wasn't reset")
+							}
+							return nil
+						}),
+					),
+				},
+			},
+		})
+	})
+	t.Run("clears user attributes on update without error", func(t *testing.T) {
+		username := "tfacc-user4"
+		orgName := "tfacc-org4"
+		email := "tfacc-user4@example.com"
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: testPritunlUserConfigWithAttributes(username, orgName, email, true),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("pritunl_user.test", "email", email),
+						resource.TestCheckResourceAttr("pritunl_user.test", "disabled", "true"),
+						resource.TestCheckResourceAttr("pritunl_user.test", "client_to_client", "true"),
+						resource.TestCheckResourceAttr("pritunl_user.test", "bypass_secondary", "true"),
+						resource.TestCheckResourceAttr("pritunl_user.test", "dns_suffix", "example.com"),
+						resource.TestCheckResourceAttr("pritunl_user.test", "yubico_id", "cccccbcdefgh"),
+					),
+				},
+				{
+					Config: testPritunlUserConfigWithAttributes(username, orgName, "", false),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("pritunl_user.test", "email", ""),
+						resource.TestCheckResourceAttr("pritunl_user.test", "disabled", "false"),
+						resource.TestCheckResourceAttr("pritunl_user.test", "client_to_client", "false"),
+						resource.TestCheckResourceAttr("pritunl_user.test", "bypass_secondary", "false"),
+						resource.TestCheckResourceAttr("pritunl_user.test", "dns_suffix", ""),
+						resource.TestCheckResourceAttr("pritunl_user.test", "yubico_id", ""),
+					),
+				},
+			},
+		})
+	})
+	t.Run("creates server users without error", func(t *testing.T) {
+		username := "tfacc-user7"
+		orgName := "tfacc-org7"
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: testPritunlUserConfigWithType(username, orgName, "server"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("pritunl_user.test", "type", "server"),
+						resource.TestCheckResourceAttr("pritunl_user.test", "status", "false"),
+					),
+				},
+				// import test
+				pritunlUserImportStep("pritunl_user.test"),
+			},
+		})
+	})
+	t.Run("removes a user deleted outside of terraform from state", func(t *testing.T) {
+		username := "tfacc-user3"
+		orgName := "tfacc-org3"
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { preCheck(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config:             testPritunlUserConfig(username, orgName),
+					Check:              testPritunlUserDisappears("pritunl_user.test"),
+					ExpectNonEmptyPlan: true,
+				},
+			},
+		})
+	})
+}
+
+func testPritunlUserDisappears(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		attributes := s.RootModule().Resources[name].Primary.Attributes
+
+		return testClient.DeleteUser(context.Background(), attributes["id"], attributes["organization_id"])
+	}
 }
 
 func testPritunlUserConfig(username, orgName string) string {
@@ -80,3 +227,70 @@ resource "pritunl_user" "test" {
 
 	return resources
 }
+
+func testPritunlUserConfigWithWriteOnlyPin(username, orgName, pin string, pinVersion int) string {
+	return fmt.Sprintf(`
+resource "pritunl_organization" "test" {
+    name = "%[2]s"
+}
+
+resource "pritunl_user" "test" {
+    name            = "%[1]s"
+    organization_id = pritunl_organization.test.id
+    pin_wo          = "%[3]s"
+    pin_wo_version  = %[4]d
+}
+`, username, orgName, pin, pinVersion)
+}
+
+func testPritunlUserConfigWithOtpResetTrigger(username, orgName, otpResetTrigger string) string {
+	return fmt.Sprintf(`
+resource "pritunl_organization" "test" {
+    name = "%[2]s"
+}
+
+resource "pritunl_user" "test" {
+    name              = "%[1]s"
+    organization_id   = pritunl_organization.test.id
+    otp_reset_trigger = "%[3]s"
+}
+`, username, orgName, otpResetTrigger)
+}
+
+func testPritunlUserConfigWithType(username, orgName, userType string) string {
+	return fmt.Sprintf(`
+resource "pritunl_organization" "test" {
+    name = "%[2]s"
+}
+
+resource "pritunl_user" "test" {
+    name            = "%[1]s"
+    organization_id = pritunl_organization.test.id
+    type            = "%[3]s"
+}
+`, username, orgName, userType)
+}
+
+func testPritunlUserConfigWithAttributes(username, orgName, email string, withFlags bool) string {
+	resources := fmt.Sprintf(`
+resource "pritunl_organization" "test" {
+    name = "%[2]s"
+}
+
+resource "pritunl_user" "test" {
+    name = "%[1]s"
+    organization_id = pritunl_organization.test.id
+    `, username, orgName)
+
+	if email != "" {
+		resources += fmt.Sprintf("email = \"%[1]s\"\n", email)
+	}
+
+	if withFlags {
+		resources += "disabled = true\nclient_to_client = true\nbypass_secondary = true\ndns_suffix = \"example.com\"\nyubico_id = \"cccccbcdefgh\"\n"
+	}
+
+	resources += "}\n"
+
+	return resources
+}
@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// serverLocks serializes changes that require a server to be offline, so
+// resources changing the same server in parallel don't restart it under each other.
+var serverLocks = &mutexKV{store: make(map[string]*sync.Mutex)}
+
+type mutexKV struct {
+	lock  sync.Mutex
+	store map[string]*sync.Mutex
+}
+
+func (m *mutexKV) Lock(key string) {
+	m.get(key).Lock()
+}
+
+func (m *mutexKV) Unlock(key string) {
+	m.get(key).Unlock()
+}
+
+func (m *mutexKV) get(key string) *sync.Mutex {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	mutex, ok := m.store[key]
+	if !ok {
+		mutex = &sync.Mutex{}
+		m.store[key] = mutex
+	}
+
+	return mutex
+}
+
+const (
+	// defaultServerStatusTimeout limits waiting for a server status outside of
+	// the pritunl_server resource, which has configurable timeouts.
+	defaultServerStatusTimeout = 10 * time.Minute
+
+	// serverOutputTailLines is the number of the last server output lines
+	// added to the error when a server fails to start.
+	serverOutputTailLines = 50
+
+	// serverStartOfflinePolls is the number of status polls a started server
+	// may still be offline for, before it's considered failed to start.
+	serverStartOfflinePolls = 3
+
+	serverStatusDeleted = "deleted"
+)
+
+// serverStatusPollInterval is the minimum interval between the server status
+// polls.
+var serverStatusPollInterval = 2 * time.Second
+
+// restart policies of the server, see the restart_policy attribute
+const (
+	serverRestartPolicyAuto             = "auto"
+	serverRestartPolicyNever            = "never"
+	serverRestartPolicyRestartOperation = "restart_operation"
+)
+
+// serverChangeRestartPolicySchema is the restart_policy attribute of the
+// resources which change a server only while it's offline, such as routes and
+// attachments. A restart operation can't be used there, so only auto and
+// never are allowed.
+func serverChangeRestartPolicySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      serverRestartPolicyAuto,
+		Description:  "Defines whether an online server may be restarted to apply the change. `auto` stops the server and starts it again, `never` fails the apply instead. Set it to the `restart_policy` of the server to keep the same guarantees.",
+		ValidateFunc: validation.StringInSlice([]string{serverRestartPolicyAuto, serverRestartPolicyNever}, false),
+	}
+}
+
+// serverHotAttributes are the server attributes applied while the server is
+// online. Changes of the other attributes require the server to be restarted,
+// which disconnects all VPN clients.
+var serverHotAttributes = map[string]bool{
+	"name":            true,
+	"pre_connect_msg": true,
+	"groups":          true,
+	"allowed_devices": true,
+}
+
+// serverAttachmentAttributes are changed with separate API calls, which Pritunl
+// only accepts while the server is offline.
+var serverAttachmentAttributes = []string{"organization_ids", "route", "host_ids"}
+
+type changeChecker interface {
+	HasChange(key string) bool
+}
+
+// serverRestartRequiredChanges returns the changed server attributes that
+// can't be applied while the server is online.
+func serverRestartRequiredChanges(d changeChecker) []string {
+	changes := make([]string, 0)
+
+	for _, key := range serverUpdatableAttributes {
+		if !serverHotAttributes[key] && d.HasChange(key) {
+			changes = append(changes, key)
+		}
+	}
+
+	for _, key := range serverAttachmentAttributes {
+		if d.HasChange(key) {
+			changes = append(changes, key)
+		}
+	}
+
+	return changes
+}
+
+func serverRestartedWarning(name string, changes []string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "Server was restarted to apply changes",
+		Detail:   fmt.Sprintf("The server %s was restarted and its VPN clients were disconnected to apply changes of: %s", name, strings.Join(changes, ", ")),
+	}
+}
+
+// withServerStopped runs fn while the server is offline. An online server is
+// stopped before fn and started again afterwards, even if fn fails. With the
+// never restart policy an online server is left untouched and fn isn't run.
+func withServerStopped(ctx context.Context, apiClient pritunl.Client, serverId, restartPolicy string, fn func() error) (err error) {
+	serverLocks.Lock(serverId)
+	defer serverLocks.Unlock(serverId)
+
+	server, err := apiClient.GetServer(ctx, serverId)
+	if err != nil {
+		return err
+	}
+
+	if server.Status != pritunl.ServerStatusOnline {
+		return fn()
+	}
+
+	if restartPolicy == serverRestartPolicyNever {
+		return fmt.Errorf("the change requires a restart of the online server %s, which is forbidden by restart_policy = %s", server.Name, serverRestartPolicyNever)
+	}
+
+	tflog.Info(ctx, "stopping server to apply changes", map[string]interface{}{"server_id": serverId})
+
+	err = apiClient.StopServer(ctx, serverId)
+	if err != nil {
+		return fmt.Errorf("Error on stopping server: %s", err)
+	}
+
+	err = waitForServerStatus(ctx, apiClient, serverId, pritunl.ServerStatusOffline, defaultServerStatusTimeout)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		startErr := apiClient.StartServer(ctx, serverId)
+		if startErr == nil {
+			startErr = waitForServerStatus(ctx, apiClient, serverId, pritunl.ServerStatusOnline, defaultServerStatusTimeout)
+		}
+		if startErr == nil {
+			return
+		}
+
+		if err != nil {
+			err = fmt.Errorf("%s; Error on starting server: %s", err, startErr)
+		} else {
+			err = fmt.Errorf("Error on starting server: %s", startErr)
+		}
+	}()
+
+	return fn()
+}
+
+// waitForServerStatus polls the server until it reaches the status. It's called
+// after the server is started, so a server still offline after a few polls has
+// failed to start. When the server doesn't come online, the error includes the
+// tail of its output log.
+func waitForServerStatus(ctx context.Context, apiClient pritunl.Client, serverId, status string, timeout time.Duration) error {
+	pending := pritunl.ServerStatusOffline
+	if status == pritunl.ServerStatusOffline {
+		pending = pritunl.ServerStatusOnline
+	}
+
+	offlinePolls := 0
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{pending},
+		Target:  []string{status},
+		Refresh: func() (interface{}, string, error) {
+			server, err := apiClient.GetServer(ctx, serverId)
+			if err != nil {
+				return nil, "", err
+			}
+
+			if status == pritunl.ServerStatusOnline && server.Status == pritunl.ServerStatusOffline {
+				offlinePolls++
+				if offlinePolls > serverStartOfflinePolls {
+					return nil, "", fmt.Errorf("server is still offline after it was started")
+				}
+			}
+
+			return server, server.Status, nil
+		},
+		Timeout:    timeout,
+		MinTimeout: serverStatusPollInterval,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	if err == nil || status != pritunl.ServerStatusOnline {
+		return err
+	}
+
+	output, outputErr := apiClient.GetServerOutput(ctx, serverId)
+	if outputErr != nil || len(output) == 0 {
+		return fmt.Errorf("server %s didn't come online: %s", serverId, err)
+	}
+	if len(output) > serverOutputTailLines {
+		output = output[len(output)-serverOutputTailLines:]
+	}
+
+	return fmt.Errorf("server %s didn't come online: %s\n\nServer output:\n%s", serverId, err, strings.Join(output, "\n"))
+}
+
+// waitForServerDeleted polls the server until Pritunl doesn't return it anymore.
+func waitForServerDeleted(ctx context.Context, apiClient pritunl.Client, serverId string, timeout time.Duration) error {
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{pritunl.ServerStatusOnline, pritunl.ServerStatusOffline},
+		Target:  []string{serverStatusDeleted},
+		Refresh: func() (interface{}, string, error) {
+			server, err := apiClient.GetServer(ctx, serverId)
+			if pritunl.IsNotFound(err) {
+				return serverId, serverStatusDeleted, nil
+			}
+			if err != nil {
+				return nil, "", err
+			}
+
+			return server, server.Status, nil
+		},
+		Timeout:    timeout,
+		MinTimeout: serverStatusPollInterval,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+
+	return err
+}
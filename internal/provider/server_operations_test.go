@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disc/terraform-provider-pritunl/internal/pritunl"
+)
+
+// serverStatusTestClient returns the statuses in order, repeating the last one.
+type serverStatusTestClient struct {
+	pritunl.Client
+
+	statuses []string
+	output   []string
+	polls    int
+}
+
+func (c *serverStatusTestClient) GetServer(ctx context.Context, id string) (*pritunl.Server, error) {
+	status := c.statuses[len(c.statuses)-1]
+	if c.polls < len(c.statuses) {
+		status = c.statuses[c.polls]
+	}
+	c.polls++
+
+	return &pritunl.Server{ID: id, Status: status}, nil
+}
+
+func (c *serverStatusTestClient) GetServerOutput(ctx context.Context, id string) ([]string, error) {
+	return c.output, nil
+}
+
+func TestWaitForServerStatus(t *testing.T) {
+	pollInterval := serverStatusPollInterval
+	serverStatusPollInterval = time.Millisecond
+	defer func() { serverStatusPollInterval = pollInterval }()
+
+	t.Run("waits for a server to come online", func(t *testing.T) {
+		apiClient := &serverStatusTestClient{statuses: []string{pritunl.ServerStatusOffline, pritunl.ServerStatusOnline}}
+
+		err := waitForServerStatus(context.Background(), apiClient, "server", pritunl.ServerStatusOnline, time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("waits for a server to go offline", func(t *testing.T) {
+		apiClient := &serverStatusTestClient{statuses: []string{pritunl.ServerStatusOnline, pritunl.ServerStatusOffline}}
+
+		err := waitForServerStatus(context.Background(), apiClient, "server", pritunl.ServerStatusOffline, time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("fails with the server output when a started server stays offline", func(t *testing.T) {
+		apiClient := &serverStatusTestClient{
+			statuses: []string{pritunl.ServerStatusOffline},
+			output:   []string{"starting server", "failed to bind port"},
+		}
+
+		err := waitForServerStatus(context.Background(), apiClient, "server", pritunl.ServerStatusOnline, time.Minute)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if !strings.Contains(err.Error(), "still offline") || !strings.Contains(err.Error(), "failed to bind port") {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if apiClient.polls != serverStartOfflinePolls+1 {
+			t.Fatalf("expected %d polls, got %d", serverStartOfflinePolls+1, apiClient.polls)
+		}
+	})
+}
@@ -4,8 +4,7 @@ import (
 	"context"
 	"flag"
 	"github.com/disc/terraform-provider-pritunl/internal/provider"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 	"log"
 )
 
@@ -17,19 +16,18 @@ func main() {
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return provider.Provider()
-		},
+	providerServer, err := provider.NewProviderServer(context.Background())
+	if err != nil {
+		log.Fatal(err.Error())
 	}
 
+	var serveOpts []tf5server.ServeOpt
 	if debugMode {
-		err := plugin.Debug(context.Background(), "registry.terraform.io/disc/pritunl", opts)
-		if err != nil {
-			log.Fatal(err.Error())
-		}
-		return
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
 	}
 
-	plugin.Serve(opts)
+	err = tf5server.Serve("registry.terraform.io/disc/pritunl", providerServer, serveOpts...)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 }